@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"tr/internal/config"
+	"tr/internal/lists"
+	"tr/internal/translator"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCmd prints a shell completion script
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for your shell. For example:
+
+  bash:  source <(tr completion bash)
+  zsh:   tr completion zsh > "${fpath[1]}/_tr"
+  fish:  tr completion fish > ~/.config/fish/completions/tr.fish`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	// Replace cobra's default completion command with our own
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	root := cmd.Root()
+	switch args[0] {
+	case "bash":
+		return root.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return root.GenZshCompletion(os.Stdout)
+	case "fish":
+		return root.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(os.Stdout)
+	}
+	return fmt.Errorf("unsupported shell: %s", args[0])
+}
+
+// completeVerbs completes the conjugate argument from the conjugation cache
+// and the bundled verb list of the --lang language
+func completeVerbs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	verbs := lexicon.Verbs(verbLang)
+	if verbLang == "es" {
+		// The conjugation cache only holds Spanish verbs
+		verbs = append(translator.New().CachedVerbs(), verbs...)
+	}
+
+	return matchCompletions(verbs, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDirections completes -d with every supported direction
+func completeDirections(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var directions []string
+	for _, from := range lang.Codes() {
+		for _, to := range lang.Codes() {
+			if from != to {
+				directions = append(directions, fmt.Sprintf("%s\t%s → %s", lang.Direction(from, to), lang.Name(from), lang.Name(to)))
+			}
+		}
+	}
+	return matchCompletions(directions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLanguages completes --from and --to with language codes
+func completeLanguages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var codes []string
+	for _, l := range lang.All() {
+		codes = append(codes, fmt.Sprintf("%s\t%s", l.Code, l.Name))
+	}
+	return matchCompletions(codes, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConjugationLanguages completes --lang with languages that have conjugations
+func completeConjugationLanguages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var codes []string
+	for _, l := range lang.All() {
+		if l.Conjugations {
+			codes = append(codes, fmt.Sprintf("%s\t%s", l.Code, l.Name))
+		}
+	}
+	return matchCompletions(codes, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// matchCompletions returns the deduplicated candidates starting with
+// toComplete, ignoring accents so "manana" completes "mañana". A tab in a
+// candidate separates it from its description.
+func matchCompletions(candidates []string, toComplete string) []string {
+	prefix := lexicon.Fold(toComplete)
+	seen := make(map[string]bool)
+	var matches []string
+	for _, candidate := range candidates {
+		value, _, _ := strings.Cut(candidate, "\t")
+		if seen[value] || !strings.HasPrefix(lexicon.Fold(value), prefix) {
+			continue
+		}
+		seen[value] = true
+		matches = append(matches, candidate)
+	}
+	return matches
+}
+
+// completeTenses completes the last name in a comma-separated --tenses list
+func completeTenses(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	done, last := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		done, last = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	var tenses []string
+	for _, tense := range matchCompletions(config.GetAvailableTenses(), last) {
+		tenses = append(tenses, done+tense)
+	}
+	return tenses, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeLists completes word list names, for --tag and the list commands
+func completeLists(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return matchCompletions(lists.Open().Names(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"tr/internal/config"
+	"tr/pkg/lang"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups the subcommands that read and change settings
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show or change settings",
+	Long: `Show or change settings. Settings: ` + strings.Join(config.Keys, ", ") + `.
+
+Values are resolved in layers, later ones winning: defaults, the user config
+file, a .tr.json in the current directory or a parent, TR_* environment
+variables (e.g. TR_DEFAULT_DIRECTION) and command-line flags.`,
+}
+
+func init() {
+	subcommands := []*cobra.Command{
+		{
+			Use:               "get [setting]",
+			Short:             "Print the value of a setting",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeSettings,
+			RunE:              runConfigGet,
+		},
+		{
+			Use:               "set [setting] [value]",
+			Short:             "Change a setting, e.g. tr config set tenses present,future",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeSettings,
+			RunE:              runConfigSet,
+		},
+		{
+			Use:   "list",
+			Short: "Print all settings",
+			Args:  cobra.NoArgs,
+			RunE:  runConfigList,
+		},
+		{
+			Use:   "path",
+			Short: "Print the location of the configuration file",
+			Args:  cobra.NoArgs,
+			Run:   runConfigPath,
+		},
+		{
+			Use:   "doctor",
+			Short: "Check the configuration and explain where each value comes from",
+			Args:  cobra.NoArgs,
+			RunE:  runConfigDoctor,
+		},
+	}
+
+	// Errors are reported by main, without the usage text
+	for _, sub := range subcommands {
+		sub.SilenceErrors = true
+		sub.SilenceUsage = true
+		configCmd.AddCommand(sub)
+	}
+
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	res, _ := config.Resolve()
+	value, err := res.Config.Get(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	// Only the user's file is written, never values from other layers
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: dropping invalid settings from %s:\n%v\n", config.Path(), err)
+	}
+
+	if err := cfg.Set(args[0], strings.Join(args[1:], " ")); err != nil {
+		return err
+	}
+	return cfg.Save()
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	res, err := config.Resolve()
+	for _, key := range config.Keys {
+		value, _ := res.Config.Get(key)
+		fmt.Printf("%s = %s\n", key, value)
+	}
+	return err
+}
+
+func runConfigPath(cmd *cobra.Command, args []string) {
+	fmt.Println(config.Path())
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	res, err := config.Resolve()
+
+	fmt.Println("Configuration files:")
+	fmt.Printf("  user:    %s%s\n", config.Path(), foundLabel(config.Path(), res.Files))
+	if project := config.ProjectPath(); project != "" {
+		fmt.Printf("  project: %s\n", project)
+	} else {
+		fmt.Println("  project: none (no .tr.json in this directory or its parents)")
+	}
+
+	fmt.Println()
+	fmt.Println("Effective settings:")
+	for _, key := range config.Keys {
+		value, _ := res.Config.Get(key)
+		origin := res.Origins[key]
+		source := origin.Source
+		if origin.Name != "" {
+			source += " " + origin.Name
+		}
+		fmt.Printf("  %-18s = %-28s (%s)\n", key, value, source)
+	}
+
+	fmt.Println()
+	if err == nil {
+		fmt.Println("No problems found.")
+		return nil
+	}
+
+	problemColor := color.New(color.FgRed)
+	fmt.Println("Problems (these settings were ignored):")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Printf("  %s\n", problemColor.Sprint(line))
+	}
+	return fmt.Errorf("the configuration has problems")
+}
+
+// foundLabel notes when a config file was not found
+func foundLabel(path string, read []string) string {
+	if slices.Contains(read, path) {
+		return ""
+	}
+	return " (not found, using defaults)"
+}
+
+// completeSettings completes setting names, then values for config set
+func completeSettings(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return matchCompletions(config.Keys, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	if cmd.Name() != "set" || len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	key, err := config.ResolveKey(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	switch key {
+	case "default_direction":
+		directions, _ := completeDirections(cmd, nil, toComplete)
+		return append(matchCompletions([]string{"auto"}, toComplete), directions...), cobra.ShellCompDirectiveNoFileComp
+	case "default_tenses":
+		return matchCompletions(config.GetAvailableTenses(), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case "region":
+		var regions []string
+		for _, region := range lang.Regions() {
+			regions = append(regions, region.Code+"\t"+region.Name)
+		}
+		return matchCompletions(regions, toComplete), cobra.ShellCompDirectiveNoFileComp
+	default:
+		return matchCompletions([]string{"on", "off"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
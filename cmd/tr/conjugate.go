@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"tr/internal/config"
+	"tr/internal/translator"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+
+	"github.com/spf13/cobra"
+)
+
+// Filters for the conjugate command
+var (
+	moodFilter   string
+	personFilter string
+	noVosotros   bool
+	voseo        bool
+	transpose    bool
+)
+
+// addConjugateFlags registers the flags that select what conjugate prints
+func addConjugateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&moodFilter, "mood", "", "Comma-separated moods to show: "+strings.Join(lang.Moods, ", "))
+	cmd.Flags().StringVar(&personFilter, "person", "", "Comma-separated persons to show, e.g. yo,nosotros")
+	cmd.Flags().BoolVar(&noVosotros, "no-vosotros", false, "Hide vosotros forms (Spanish)")
+	cmd.Flags().BoolVar(&voseo, "voseo", false, "Add vos forms (Spanish)")
+	cmd.Flags().BoolVar(&transpose, "transpose", false, "Show tenses as rows and persons as columns")
+
+	cmd.RegisterFlagCompletionFunc("mood", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchCompletions(lang.Moods, toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// hasConjugateFilters reports whether any filter flag was given
+func hasConjugateFilters(cmd *cobra.Command) bool {
+	for _, name := range []string{"tenses", "mood", "person", "no-vosotros", "voseo", "transpose"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// conjugateTableOptions builds the table selection from the filter flags.
+// Without --tenses or --mood the configured tenses are shown.
+func conjugateTableOptions(language string, cfg *config.Config) (translator.TableOptions, error) {
+	opts := translator.TableOptions{Transpose: transpose}
+
+	if (noVosotros || voseo) && language != "es" {
+		return opts, fmt.Errorf("--no-vosotros and --voseo only apply to Spanish")
+	}
+
+	// Tenses: explicit list, else all of a mood, else the configured ones
+	available := lang.Tenses(language)
+	tenses := available
+	switch {
+	case tensesFlag != "":
+		tenses = splitList(tensesFlag)
+		for _, tense := range tenses {
+			if !slices.Contains(available, tense) {
+				return opts, fmt.Errorf("unknown tense %q (valid: %s)", tense, strings.Join(available, ", "))
+			}
+		}
+	case moodFilter == "" && !cfg.ShowAllTenses:
+		tenses = cfg.DefaultTenses
+	}
+
+	if moodFilter != "" {
+		moods := splitList(moodFilter)
+		for _, mood := range moods {
+			if !slices.Contains(lang.Moods, mood) {
+				return opts, fmt.Errorf("unknown mood %q (valid: %s)", mood, strings.Join(lang.Moods, ", "))
+			}
+		}
+		tenses = slices.DeleteFunc(slices.Clone(tenses), func(tense string) bool {
+			return !slices.Contains(moods, lang.Mood(tense))
+		})
+	}
+	opts.Tenses = tenses
+
+	// Persons: explicit list or the region's persons, then regional tweaks
+	persons := lang.RegionPersons(language, cfg.Region)
+	if voseo && !slices.Contains(persons, "vos") {
+		persons = slices.Insert(persons, slices.Index(persons, "tú")+1, "vos")
+	}
+	if personFilter != "" {
+		var selected []string
+		for _, name := range splitList(personFilter) {
+			person, ok := matchPerson(persons, name)
+			if !ok {
+				return opts, fmt.Errorf("unknown person %q (valid: %s)", name, strings.Join(persons, ", "))
+			}
+			selected = append(selected, person)
+		}
+		persons = selected
+	}
+	if noVosotros {
+		persons = slices.DeleteFunc(persons, func(p string) bool { return p == "vosotros" })
+	}
+	opts.Persons = persons
+
+	return opts, nil
+}
+
+// matchPerson finds a person by name, ignoring accents and accepting either
+// half of a combined person like "él/ella"
+func matchPerson(persons []string, name string) (string, bool) {
+	name = lexicon.Fold(name)
+	for _, person := range persons {
+		for _, part := range strings.Split(person, "/") {
+			if lexicon.Fold(part) == name || lexicon.Fold(person) == name {
+				return person, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitList splits a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(strings.ToLower(value), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
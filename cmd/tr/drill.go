@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"tr/internal/config"
+	"tr/internal/drill"
+	"tr/internal/translator"
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Options for the drill command
+var (
+	drillVerbs     string
+	drillIrregular bool
+	drillCount     int
+)
+
+var drillCmd = &cobra.Command{
+	Use:   "drill",
+	Short: "Practice Spanish conjugations by typing the forms",
+	Long: `Practice Spanish conjugations: you get a verb, tense and person and type
+the form. Verbs come from the ones you looked up before (the conjugation
+cache) unless --verbs is given. Answers with the right letters but a missing
+accent are pointed out separately. Press Ctrl+D to stop early.`,
+	Args: cobra.NoArgs,
+	Run:  runDrill,
+}
+
+var drillStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show drill accuracy per tense",
+	Args:  cobra.NoArgs,
+	Run:   runDrillStats,
+}
+
+func init() {
+	drillCmd.Flags().StringVar(&drillVerbs, "verbs", "", "Comma-separated verbs to drill, e.g. ser,tener,ir")
+	drillCmd.Flags().BoolVar(&drillIrregular, "irregular", false, "Only drill irregular verbs")
+	drillCmd.Flags().IntVarP(&drillCount, "count", "n", 10, "Number of questions")
+
+	drillCmd.AddCommand(drillStatsCmd)
+	rootCmd.AddCommand(drillCmd)
+}
+
+func runDrill(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+
+	tenses := config.GetAvailableTenses()
+	if tensesFlag != "" {
+		available := tenses
+		tenses = splitList(tensesFlag)
+		for _, tense := range tenses {
+			if !slices.Contains(available, tense) {
+				fmt.Fprintf(os.Stderr, "Error: unknown tense %q (valid: %s)\n", tense, strings.Join(available, ", "))
+				os.Exit(1)
+			}
+		}
+	}
+
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+	defer t.Flush()
+
+	names := splitList(drillVerbs)
+	if len(names) == 0 {
+		names = t.CachedVerbs()
+	}
+	if len(names) == 0 {
+		fmt.Println("No verbs to drill yet. Look up some verbs first (e.g. tr hablar) or pass --verbs.")
+		return
+	}
+
+	verbs := make(map[string]map[string]map[string]string)
+	for _, verb := range names {
+		conjugations, err := t.GetConjugations("es", verb)
+		if err != nil || len(conjugations) == 0 {
+			fmt.Fprintf(os.Stderr, "Skipping %s: no conjugations found\n", verb)
+			continue
+		}
+		if drillIrregular && !conjugation.IsIrregular("es", verb, conjugations) {
+			continue
+		}
+		verbs[verb] = conjugations
+	}
+
+	tenses = drill.Tenses(verbs, tenses)
+	if len(tenses) == 0 {
+		fmt.Println("No matching verbs or tenses to drill.")
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	ask := func(prompt string) (string, bool) {
+		fmt.Print(prompt)
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	}
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	correct, asked := drill.Run(verbs, tenses, cfg.Region, ask, os.Stdout, drillCount, rnd, drill.LoadStats())
+
+	fmt.Printf("\n%s\n", color.New(color.FgCyan, color.Bold).Sprintf("Score: %d/%d", correct, asked))
+	fmt.Println("Run 'tr drill stats' to see your accuracy per tense.")
+}
+
+func runDrillStats(cmd *cobra.Command, args []string) {
+	stats := drill.LoadStats()
+	if len(stats.Tenses) == 0 {
+		fmt.Println("No drill results yet. Run 'tr drill' to start.")
+		return
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("Accuracy per tense"))
+	for _, tense := range lang.Tenses("es") {
+		s, ok := stats.Tenses[tense]
+		if !ok || s.Total() == 0 {
+			continue
+		}
+
+		accuracy := s.Accuracy() * 100
+		accuracyColor := color.New(color.FgGreen)
+		switch {
+		case accuracy < 50:
+			accuracyColor = color.New(color.FgRed)
+		case accuracy < 80:
+			accuracyColor = color.New(color.FgYellow)
+		}
+		fmt.Printf("  %-28s %s  (%d answers, %d missing accents)\n",
+			strings.ReplaceAll(tense, "_", " "), accuracyColor.Sprintf("%3.0f%%", accuracy), s.Total(), s.MissingAccent)
+	}
+}
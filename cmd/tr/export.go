@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"tr/internal/anki"
+	"tr/internal/lists"
+	"tr/internal/translator"
+	"tr/internal/vocab"
+	"tr/pkg/lang"
+
+	"github.com/spf13/cobra"
+)
+
+// Options for the export anki command
+var (
+	exportOutput    string
+	exportFormat    string
+	exportDeck      string
+	exportInclude   string
+	exportTag       string
+	exportTemplates = anki.DefaultTemplates
+)
+
+// exportSources are the kinds of cards export can include
+var exportSources = []string{"vocab", "conjugations"}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export saved words and conjugations",
+}
+
+var exportAnkiCmd = &cobra.Command{
+	Use:   "anki",
+	Short: "Export flashcards for Anki as an .apkg deck or TSV file",
+	Long: `Export flashcards for Anki from the words you looked up and the cached
+verb conjugations. The output format follows the file extension (.apkg, or
+.tsv/.txt for Anki's text import) unless --format is given; -o - writes TSV
+to standard output.
+
+Card sides are Go templates. Vocabulary cards can use {{.Word}},
+{{.Translation}}, {{.From}}, {{.To}} and {{.IsVerb}}; conjugation cards can
+use {{.Verb}}, {{.Tense}}, {{.Mood}}, {{.Person}} and {{.Form}}. HTML in a
+template, such as <b>{{.Form}}</b>, is kept and the values are escaped.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runExportAnki,
+}
+
+func init() {
+	flags := exportAnkiCmd.Flags()
+	flags.StringVarP(&exportOutput, "output", "o", "tr.apkg", "Output file")
+	flags.StringVar(&exportFormat, "format", "", "Output format: apkg or tsv (default from the file extension)")
+	flags.StringVar(&exportDeck, "deck", "tr", "Deck name (apkg)")
+	flags.StringVar(&exportInclude, "include", strings.Join(exportSources, ","), "Cards to include: vocab, conjugations")
+	flags.StringVar(&exportTag, "tag", "", "Only export words on these comma-separated lists")
+	flags.StringVar(&exportTemplates.WordFront, "word-front", exportTemplates.WordFront, "Template for the front of vocabulary cards")
+	flags.StringVar(&exportTemplates.WordBack, "word-back", exportTemplates.WordBack, "Template for the back of vocabulary cards")
+	flags.StringVar(&exportTemplates.FormFront, "form-front", exportTemplates.FormFront, "Template for the front of conjugation cards")
+	flags.StringVar(&exportTemplates.FormBack, "form-back", exportTemplates.FormBack, "Template for the back of conjugation cards")
+
+	exportAnkiCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchCompletions([]string{"apkg", "tsv"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+	exportAnkiCmd.RegisterFlagCompletionFunc("tag", completeLists)
+
+	exportCmd.AddCommand(exportAnkiCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExportAnki(cmd *cobra.Command, args []string) error {
+	format, err := exportFileFormat()
+	if err != nil {
+		return err
+	}
+	include := splitList(exportInclude)
+	for _, source := range include {
+		if !slices.Contains(exportSources, source) {
+			return fmt.Errorf("unknown --include %q (valid: %s)", source, strings.Join(exportSources, ", "))
+		}
+	}
+
+	var tagged []lists.Item
+	if exportTag != "" {
+		if tagged, err = taggedItems(exportTag); err != nil {
+			return err
+		}
+	}
+
+	cfg := loadConfig(cmd)
+	var cards []anki.Card
+
+	if slices.Contains(include, "vocab") {
+		entries := vocab.Open().Entries()
+		if tagged != nil {
+			entries = slices.DeleteFunc(entries, func(e *vocab.Entry) bool {
+				return !isTagged(tagged, e.Word, e.From, e.To)
+			})
+		}
+		wordCards, err := anki.WordCards(entries, exportTemplates)
+		if err != nil {
+			return err
+		}
+		cards = append(cards, wordCards...)
+	}
+
+	if slices.Contains(include, "conjugations") {
+		tenses := cfg.DefaultTenses
+		if cfg.ShowAllTenses {
+			tenses = lang.Tenses("es")
+		}
+		if tensesFlag != "" {
+			tenses = splitList(tensesFlag)
+			for _, tense := range tenses {
+				if !slices.Contains(lang.Tenses("es"), tense) {
+					return fmt.Errorf("unknown tense %q (valid: %s)", tense, strings.Join(lang.Tenses("es"), ", "))
+				}
+			}
+		}
+
+		// Only cached verbs are exported, so this never goes to the network
+		t := translator.New()
+		t.SetRegion(cfg.Region)
+		verbs := t.CachedVerbs()
+		if tagged != nil {
+			verbs = slices.DeleteFunc(verbs, func(verb string) bool {
+				return !slices.Contains(taggedVerbs(tagged, "es"), verb)
+			})
+		}
+		tables := make(map[string]map[string]map[string]string)
+		for _, verb := range verbs {
+			if conjugations, err := t.GetConjugations("es", verb); err == nil {
+				tables[verb] = conjugations
+			}
+		}
+
+		formCards, err := anki.FormCards("es", verbs, tables, tenses, lang.RegionPersons("es", cfg.Region), exportTemplates)
+		if err != nil {
+			return err
+		}
+		cards = append(cards, formCards...)
+	}
+
+	if len(cards) == 0 {
+		fmt.Println("Nothing to export yet. Translate some words or conjugate some verbs first.")
+		return nil
+	}
+
+	switch {
+	case exportOutput == "-":
+		return anki.WriteTSV(os.Stdout, cards)
+	case format == "tsv":
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportOutput, err)
+		}
+		defer f.Close()
+		if err := anki.WriteTSV(f, cards); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+		}
+	default:
+		if err := anki.WriteAPKG(exportOutput, exportDeck, cards, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Exported %d cards to %s\n", len(cards), exportOutput)
+	return nil
+}
+
+// exportFileFormat returns the format from --format or the output extension
+func exportFileFormat() (string, error) {
+	switch exportFormat {
+	case "apkg", "tsv":
+		return exportFormat, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown format %q (valid: apkg, tsv)", exportFormat)
+	}
+
+	switch strings.ToLower(filepath.Ext(exportOutput)) {
+	case ".tsv", ".txt":
+		return "tsv", nil
+	default:
+		return "apkg", nil
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"tr/internal/importer"
+	"tr/internal/lists"
+	"tr/internal/translator"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Options for the import command
+var (
+	importDirection string
+	importList      string
+	importOptions   = importer.DefaultOptions
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Translate and conjugate a word list ahead of time for offline use",
+	Long: `Translate every word in a file (one per line, - for standard input) and
+fetch the conjugations of its verbs, filling the caches so they can be looked
+up offline later. Lines starting with # are skipped and anything after a tab
+or " - " is ignored, so "hablar - to speak" imports hablar.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importDirection, "direction", "d", "", "Translation direction, e.g. es2en (default from default_direction)")
+	importCmd.Flags().StringVar(&importList, "list", "", "Also add the imported words to this list")
+	importCmd.Flags().IntVar(&importOptions.Workers, "workers", importOptions.Workers, "Number of concurrent lookups")
+	importCmd.Flags().Float64Var(&importOptions.Rate, "rate", importOptions.Rate, "Maximum requests per second, 0 for no limit")
+	importCmd.RegisterFlagCompletionFunc("direction", completeDirections)
+	importCmd.RegisterFlagCompletionFunc("list", completeLists)
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	in := os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open word list: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	words, err := importer.ReadWords(in)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("no words found in %s", args[0])
+	}
+
+	cfg := loadConfig(cmd)
+	defaultDirection := cfg.DefaultDirection
+	if importDirection != "" {
+		defaultDirection = importDirection
+	}
+
+	jobs := make([]importer.Job, len(words))
+	for i, word := range words {
+		from, to, err := determineDirection(defaultDirection, "", "", word)
+		if err != nil {
+			return err
+		}
+		jobs[i] = importer.Job{Word: word, From: from, To: to}
+	}
+
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+	results := importer.Run(t, jobs, importOptions, progressBar(os.Stderr, "Importing"))
+
+	return reportImport(results)
+}
+
+// reportImport prints a summary and the words that failed, and adds the
+// imported words to --list. Low-confidence translations aren't cached, so
+// they are listed apart and left off the list. It returns an error if any
+// word failed.
+func reportImport(results []importer.Result) error {
+	var imported, cached, conjugated int
+	var uncached, failures []string
+	store := lists.Open()
+	for _, r := range results {
+		if r.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.Word, r.Err))
+			continue
+		}
+		if r.ConjugationsErr != nil {
+			failures = append(failures, fmt.Sprintf("%s (conjugations): %v", r.Word, r.ConjugationsErr))
+		}
+		if r.Conjugated {
+			conjugated++
+		}
+		if r.LowConfidence {
+			uncached = append(uncached, r.Word)
+			continue
+		}
+
+		imported++
+		if r.Cached {
+			cached++
+		}
+		if importList != "" {
+			if _, err := store.Add(importList, r.Translation, r.From, r.To, time.Now()); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("%s %d of %d words (%d already cached), %d verbs conjugated\n",
+		color.New(color.FgGreen, color.Bold).Sprint("Imported"), imported, len(results), cached, conjugated)
+	if importList != "" {
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("Added them to the list %s\n", importList)
+	}
+
+	if len(uncached) > 0 {
+		warnColor := color.New(color.FgYellow)
+		fmt.Printf("\n%s\n", warnColor.Sprintf("%d not cached (low confidence):", len(uncached)))
+		for _, word := range uncached {
+			fmt.Printf("  %s\n", word)
+		}
+	}
+
+	if len(failures) > 0 {
+		errorColor := color.New(color.FgRed)
+		fmt.Printf("\n%s\n", errorColor.Sprintf("%d failed:", len(failures)))
+		for _, failure := range failures {
+			fmt.Printf("  %s\n", failure)
+		}
+		return fmt.Errorf("%d of %d words failed to import", len(failures), len(results))
+	}
+	return nil
+}
+
+// progressBar returns a progress callback that redraws a bar on w, or nil
+// if w is not a terminal
+func progressBar(w *os.File, label string) func(done, total int) {
+	if !term.IsTerminal(int(w.Fd())) {
+		return nil
+	}
+
+	const width = 30
+	return func(done, total int) {
+		filled := done * width / total
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+		fmt.Fprintf(w, "\r%s %s %d/%d", label, bar, done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
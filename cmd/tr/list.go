@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"tr/internal/config"
+	"tr/internal/lists"
+	"tr/internal/translator"
+	"tr/internal/vocab"
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Options for the list export command
+var (
+	listExportOutput string
+	listExportFormat string
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Organize words into named lists",
+	Long: `Organize words into named lists such as "week 3" or "kitchen verbs". With no
+subcommand, prints the lists. List names double as tags: pass --tag to tr,
+tr conjugate or tr export anki to work on the words of a list.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runListIndex,
+}
+
+func init() {
+	subcommands := []*cobra.Command{
+		{
+			Use:               "add [list] [word...]",
+			Short:             "Add words to a list, creating it if needed",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeListArg,
+			RunE:              runListAdd,
+		},
+		{
+			Use:               "rm [list] [word...]",
+			Short:             "Remove words from a list, or the whole list",
+			Args:              cobra.MinimumNArgs(1),
+			ValidArgsFunction: completeListArg,
+			RunE:              runListRemove,
+		},
+		{
+			Use:               "show [list]",
+			Short:             "Print the words on a list",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeListArg,
+			RunE:              runListShow,
+		},
+		{
+			Use:               "export [list]",
+			Short:             "Write a list as TSV or CSV",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeListArg,
+			RunE:              runListExport,
+		},
+	}
+
+	// Errors are reported by main, without the usage text
+	for _, sub := range subcommands {
+		sub.SilenceErrors = true
+		sub.SilenceUsage = true
+		listCmd.AddCommand(sub)
+	}
+
+	export := subcommands[3]
+	export.Flags().StringVarP(&listExportOutput, "output", "o", "-", "Output file, - for standard output")
+	export.Flags().StringVar(&listExportFormat, "format", "tsv", "Output format: tsv or csv")
+
+	rootCmd.AddCommand(listCmd)
+}
+
+// completeListArg completes the list name argument of the list commands
+func completeListArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeLists(cmd, args, toComplete)
+}
+
+func runListIndex(cmd *cobra.Command, args []string) error {
+	store := lists.Open()
+	names := store.Names()
+	if len(names) == 0 {
+		fmt.Println("No lists yet. Create one with: tr list add \"week 3\" hablar comer")
+		return nil
+	}
+
+	nameColor := color.New(color.FgGreen)
+	for _, name := range names {
+		l, _ := store.Get(name)
+		fmt.Printf("  %s (%d words)\n", nameColor.Sprint(l.Name), len(l.Items))
+	}
+	return nil
+}
+
+func runListAdd(cmd *cobra.Command, args []string) error {
+	name, words := args[0], args[1:]
+	cfg := loadConfig(cmd)
+	store := lists.Open()
+	saved := vocab.Open()
+
+	var t translator.Translator
+	defer func() {
+		if t != nil {
+			t.Flush()
+		}
+	}()
+	for _, word := range words {
+		from, to, err := determineDirection(cfg.DefaultDirection, "", "", word)
+		if err != nil {
+			return err
+		}
+
+		// Reuse the translation of words looked up before
+		var result *translator.TranslationResult
+		if e := saved.Find(word, from, to); e != nil {
+			result = &translator.TranslationResult{OriginalText: e.Word, Translation: e.Translation, IsVerb: e.IsVerb}
+		} else {
+			if t == nil {
+				t = translator.New()
+				t.SetRegion(cfg.Region)
+			}
+			if result, err = t.Translate(word, from, to); err != nil {
+				return fmt.Errorf("failed to translate %q: %w", word, err)
+			}
+			if !translator.IsLowConfidence(result) {
+				saved.Record(result, from, to)
+			}
+		}
+
+		added, err := store.Add(name, result, from, to, time.Now())
+		if err != nil {
+			return err
+		}
+		if added {
+			fmt.Printf("Added %s (%s) to %s\n", result.OriginalText, result.Translation, name)
+		} else {
+			fmt.Printf("%s is already on %s\n", result.OriginalText, name)
+		}
+	}
+	return store.Save()
+}
+
+func runListRemove(cmd *cobra.Command, args []string) error {
+	store := lists.Open()
+	removed, err := store.Remove(args[0], args[1:]...)
+	if err != nil {
+		return err
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		fmt.Printf("Deleted list %s (%d words)\n", args[0], removed)
+	} else {
+		fmt.Printf("Removed %d words from %s\n", removed, args[0])
+	}
+	return nil
+}
+
+func runListShow(cmd *cobra.Command, args []string) error {
+	l, ok := lists.Open().Get(args[0])
+	if !ok {
+		return fmt.Errorf("no list named %q", args[0])
+	}
+	if len(l.Items) == 0 {
+		fmt.Printf("%s is empty\n", l.Name)
+		return nil
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint(l.Name))
+	displayItems(l.Items)
+	return nil
+}
+
+func runListExport(cmd *cobra.Command, args []string) error {
+	l, ok := lists.Open().Get(args[0])
+	if !ok {
+		return fmt.Errorf("no list named %q", args[0])
+	}
+
+	var out io.Writer = os.Stdout
+	if listExportOutput != "-" {
+		f, err := os.Create(listExportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", listExportOutput, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := csv.NewWriter(out)
+	switch listExportFormat {
+	case "tsv":
+		w.Comma = '\t'
+	case "csv":
+	default:
+		return fmt.Errorf("unknown format %q (valid: tsv, csv)", listExportFormat)
+	}
+
+	w.Write([]string{"word", "translation", "from", "to"})
+	for _, item := range l.Items {
+		w.Write([]string{item.Word, item.Translation, item.From, item.To})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// displayItems prints list items as translation tables, one per direction
+func displayItems(items []lists.Item) {
+	var directions []string
+	byDirection := make(map[string][]*translator.TranslationResult)
+	for _, item := range items {
+		direction := lang.Direction(item.From, item.To)
+		if _, ok := byDirection[direction]; !ok {
+			directions = append(directions, direction)
+		}
+		byDirection[direction] = append(byDirection[direction], &translator.TranslationResult{
+			OriginalText: item.Word,
+			Translation:  item.Translation,
+			IsVerb:       item.IsVerb,
+		})
+	}
+
+	for _, direction := range directions {
+		from, to, _ := lang.ParseDirection(direction)
+		translator.DisplayTranslations(byDirection[direction], from, to)
+	}
+}
+
+// taggedItems returns the words on the lists named in a comma-separated
+// --tag value
+func taggedItems(tags string) ([]lists.Item, error) {
+	items, err := lists.Open().Tagged(splitList(tags)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no words tagged %s", tags)
+	}
+	return items, nil
+}
+
+// runTaggedLookup translates every word on the tagged lists again and
+// prints them as tables, one per direction
+func runTaggedLookup(cfg *config.Config, tags string) error {
+	items, err := taggedItems(tags)
+	if err != nil {
+		return err
+	}
+
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+	defer t.Flush()
+	saved := vocab.Open()
+
+	var looked []lists.Item
+	for _, item := range items {
+		result, err := t.Translate(item.Word, item.From, item.To)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to translate %s: %v\n", item.Word, err)
+			continue
+		}
+		if !translator.IsLowConfidence(result) {
+			saved.Record(result, item.From, item.To)
+		}
+		item.Translation = result.Translation
+		looked = append(looked, item)
+	}
+
+	displayItems(looked)
+	return nil
+}
+
+// isTagged reports whether a word in a direction is on the tagged items
+func isTagged(items []lists.Item, word, from, to string) bool {
+	for _, item := range items {
+		if item.From == from && item.To == to && strings.EqualFold(item.Word, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// taggedVerbs returns the tagged words that are verbs in a language
+func taggedVerbs(items []lists.Item, language string) []string {
+	var verbs []string
+	for _, item := range items {
+		if item.From == language && (item.IsVerb || conjugation.IsLikelyVerb(language, item.Word)) {
+			verbs = append(verbs, strings.ToLower(item.Word))
+		}
+	}
+	return verbs
+}
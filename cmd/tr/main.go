@@ -3,48 +3,90 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 
+	"tr/internal/config"
+	"tr/internal/prompt"
 	"tr/internal/repl"
 	"tr/internal/translator"
+	"tr/internal/vocab"
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	version   = "1.0.0"
-	direction string
+	version    = "1.0.0"
+	direction  string
+	fromFlag   string
+	toFlag     string
+	verbLang   string
+	tensesFlag string
+	allFlag    bool
+	tagFlag    string
+	verbTag    string
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:     "tr [text]",
-	Short:   "Translate between English and Spanish",
-	Long:    `TR is a command-line tool for translating between English and Spanish with interactive REPL mode and verb conjugations.`,
+	Short:   "Translate between Spanish, English and other languages",
+	Long:    `TR is a command-line tool for translating between English and Spanish (and other language pairs) with interactive REPL mode and verb conjugations.`,
 	Version: version,
 	Args:    cobra.ArbitraryArgs,
 	Run:     runTranslate,
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&direction, "direction", "d", "", "Translation direction: es2en or en2es")
+	rootCmd.Flags().StringVarP(&direction, "direction", "d", "", "Translation direction as <from>2<to>, e.g. es2en, en2es or fr2es")
+	rootCmd.Flags().StringVar(&fromFlag, "from", "", "Source language code, e.g. pt")
+	rootCmd.Flags().StringVar(&toFlag, "to", "", "Target language code, e.g. en")
+	rootCmd.Flags().StringVar(&tagFlag, "tag", "", "Look up every word on these comma-separated lists")
+	rootCmd.PersistentFlags().StringVar(&tensesFlag, "tenses", "", "Comma-separated tenses to show, e.g. present,future")
+	rootCmd.PersistentFlags().BoolVar(&allFlag, "all", false, "Show all tenses")
 
 	// Add conjugate subcommand
 	var conjugateCmd = &cobra.Command{
 		Use:   "conjugate [verb]",
 		Short: "Show conjugations for a Spanish verb",
-		Long:  `Display conjugation tables for Spanish verbs with expandable tenses.`,
-		Args:  cobra.ExactArgs(1),
+		Long:  `Display conjugation tables for Spanish verbs with expandable tenses. Portuguese, French and Italian verbs are conjugated offline with --lang.`,
+		Args:  cobra.RangeArgs(0, 1),
 		Run:   runConjugate,
+
+		ValidArgsFunction: completeVerbs,
 	}
+	conjugateCmd.Flags().StringVarP(&verbLang, "lang", "l", "es", "Language of the verb: es, pt, fr or it")
+	conjugateCmd.Flags().StringVar(&verbTag, "tag", "", "Conjugate every verb on these comma-separated lists")
+	conjugateCmd.RegisterFlagCompletionFunc("lang", completeConjugationLanguages)
+	conjugateCmd.RegisterFlagCompletionFunc("tag", completeLists)
+	addConjugateFlags(conjugateCmd)
+
+	// Complete directions and language codes in the shell
+	rootCmd.RegisterFlagCompletionFunc("direction", completeDirections)
+	rootCmd.RegisterFlagCompletionFunc("from", completeLanguages)
+	rootCmd.RegisterFlagCompletionFunc("to", completeLanguages)
+	rootCmd.RegisterFlagCompletionFunc("tenses", completeTenses)
+	rootCmd.RegisterFlagCompletionFunc("tag", completeLists)
 
 	rootCmd.AddCommand(conjugateCmd)
 }
 
 func runTranslate(cmd *cobra.Command, args []string) {
+	// Look up the words on the tagged lists
+	if tagFlag != "" {
+		if err := runTaggedLookup(loadConfig(cmd), tagFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// If no arguments provided, start interactive REPL mode
 	if len(args) == 0 {
 		fmt.Println("Starting interactive mode...")
-		repl := repl.New()
+		repl := repl.New(flagOverrides(cmd)...)
 		if err := repl.Start(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error starting REPL: %v\n", err)
 			os.Exit(1)
@@ -66,59 +108,96 @@ func runTranslate(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Determine translation direction
-	fromLang, toLang := determineDirection(direction, text)
+	// Determine translation direction, falling back to the configured default
+	cfg := loadConfig(cmd)
+	fromLang, toLang, err := determineDirection(cfg.DefaultDirection, fromFlag, toFlag, text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Create translator and perform translation
+	// Expand accent shortcuts like n~ and a' in Spanish input
+	text = lexicon.ExpandShortcuts(fromLang, text)
+
+	// Create translator and perform translation, correcting missing accents
 	t := translator.New()
-	result, err := t.Translate(text, fromLang, toLang)
+	t.SetRegion(cfg.Region)
+	defer t.Flush()
+	result, correction, err := translator.TranslateWithCorrection(t, text, fromLang, toLang)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Translation error: %v\n", err)
 		os.Exit(1)
 	}
+	if correction != nil {
+		text = correction.Corrected
+	}
 
 	// Display results
+	translator.DisplayCorrection(correction)
 	displayResult(result, fromLang, toLang)
+	if !translator.IsLowConfidence(result) {
+		vocab.Open().Record(result, fromLang, toLang)
+	}
 
-	// If it's a Spanish verb, show conjugations
-	if fromLang == "es" && result.IsVerb {
-		conjugations, err := t.GetConjugations(text)
+	// If it's a verb in a language with conjugation support, show conjugations
+	if lang.SupportsConjugation(fromLang) && result.IsVerb {
+		conjugations, err := t.GetConjugations(fromLang, text)
 		if err == nil && len(conjugations) > 0 {
 			fmt.Println()
-			displayConjugations(conjugations)
+			displayConjugations(fromLang, text, conjugations, cfg)
 		}
 	}
 }
 
-func determineDirection(direction, text string) (from, to string) {
-	switch direction {
-	case "es2en":
-		return "es", "en"
-	case "en2es":
-		return "en", "es"
-	default:
-		// Auto-detect based on text characteristics
-		if isLikelySpanish(text) {
-			return "es", "en"
-		}
-		return "en", "es"
+func determineDirection(direction, from, to, text string) (string, string, error) {
+	switch {
+	case from != "" && to != "":
+		return lang.ParsePair(from, to)
+	case from != "":
+		return lang.ParsePair(from, lang.Counterpart(from))
+	case to != "":
+		return lang.ParsePair(lang.Counterpart(to), to)
+	case direction != "" && direction != "auto":
+		return lang.ParseDirection(direction)
+	}
+
+	// Auto-detect between Spanish and English from the text itself
+	if lang.Detect(text, "es", "en") == "en" {
+		return "en", "es", nil
 	}
+	return "es", "en", nil
 }
 
-func isLikelySpanish(text string) bool {
-	// Simple heuristic: check for Spanish-specific characters
-	spanishChars := "ñáéíóúü¿¡"
-	for _, char := range text {
-		for _, sChar := range spanishChars {
-			if char == sChar {
-				return true
-			}
+// flagOverrides returns the settings given as flags, exiting on invalid values
+func flagOverrides(cmd *cobra.Command) []config.Override {
+	var overrides []config.Override
+	if direction != "" {
+		overrides = append(overrides, config.Override{Key: "default_direction", Value: direction, Flag: "--direction"})
+	}
+	if cmd.Flags().Changed("tenses") {
+		overrides = append(overrides, config.Override{Key: "default_tenses", Value: tensesFlag, Flag: "--tenses"})
+	}
+	if cmd.Flags().Changed("all") {
+		overrides = append(overrides, config.Override{Key: "show_all_tenses", Value: strconv.FormatBool(allFlag), Flag: "--all"})
+	}
+
+	// Flags are checked up front; invalid config files only warn
+	for _, o := range overrides {
+		if err := config.DefaultConfig().Set(o.Key, o.Value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", o.Flag, err)
+			os.Exit(1)
 		}
 	}
+	return overrides
+}
 
-	// Could add more sophisticated detection here
-	// For now, default to assuming input is Spanish
-	return true
+// loadConfig resolves the configuration with flags applied on top
+func loadConfig(cmd *cobra.Command) *config.Config {
+	cfg, err := config.LoadConfig(flagOverrides(cmd)...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid settings (run 'tr config doctor' for details):\n%v\n", err)
+	}
+	return cfg
 }
 
 func displayResult(result *translator.TranslationResult, fromLang, toLang string) {
@@ -126,29 +205,102 @@ func displayResult(result *translator.TranslationResult, fromLang, toLang string
 	translator.DisplayTranslation(result, fromLang, toLang)
 }
 
-func displayConjugations(conjugations map[string]map[string]string) {
-	translator.DisplayConjugations(conjugations)
+// displayConjugations shows the configured tenses, pointing to --all for the rest
+func displayConjugations(language, verb string, conjugations conjugation.Table, cfg *config.Config) {
+	session := translator.NewSession()
+	session.ExpandHint = func(language, verb string) string {
+		if language != "es" {
+			return fmt.Sprintf("Run 'tr conjugate %s --lang %s --all' to see all conjugations.", verb, language)
+		}
+		return fmt.Sprintf("Run 'tr conjugate %s --all' to see all conjugations.", verb)
+	}
+	session.SetVerb(language, verb)
+
+	persons := lang.RegionPersons(language, cfg.Region)
+	translator.DisplayConjugationsExpandable(session, language, conjugations, persons, cfg.DefaultTenses, cfg.ShowAllTenses)
 }
 
 func runConjugate(cmd *cobra.Command, args []string) {
-	verb := args[0]
+	if !lang.SupportsConjugation(verbLang) {
+		fmt.Fprintf(os.Stderr, "Error: conjugations are not supported for %s\n", lang.Name(verbLang))
+		os.Exit(1)
+	}
 
-	// Create translator and get conjugations
-	t := translator.New()
+	// Conjugate the verb given, or every verb on the tagged lists
+	var verbs []string
+	switch {
+	case verbTag != "":
+		items, err := taggedItems(verbTag)
+		if err == nil {
+			verbs = taggedVerbs(items, verbLang)
+			if len(verbs) == 0 {
+				err = fmt.Errorf("no %s verbs tagged %s", lang.Name(verbLang), verbTag)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case len(args) == 0:
+		fmt.Fprintln(os.Stderr, "Error: give a verb to conjugate, or --tag to conjugate the verbs on a list")
+		os.Exit(1)
+	default:
+		verbs = args[:1]
+	}
 
-	conjugations, err := t.GetConjugations(verb)
+	// Check the filter flags before looking anything up
+	cfg := loadConfig(cmd)
+	opts, err := conjugateTableOptions(verbLang, cfg)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting conjugations: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Create translator and print one table per verb
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+	for i, verb := range verbs {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := conjugateOne(cmd, t, cfg, opts, verb); err != nil {
+			t.Flush()
+			fmt.Fprintf(os.Stderr, "Error getting conjugations: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	t.Flush()
+}
+
+// conjugateOne looks up and prints the conjugations of a verb. For an
+// unknown verb it offers close verbs from the lexicon and conjugates the one
+// picked.
+func conjugateOne(cmd *cobra.Command, t translator.Translator, cfg *config.Config, opts translator.TableOptions, verb string) error {
+	conjugations, err := t.GetConjugations(verbLang, verb)
+	if err != nil && len(lexicon.SuggestVerbs(verbLang, verb, 1)) == 0 {
+		return err
+	}
+
 	if len(conjugations) == 0 {
 		fmt.Printf("No conjugations found for verb: %s\n", verb)
-		return
+		if choice, ok := prompt.Choose(os.Stdin, "Did you mean:", lexicon.SuggestVerbs(verbLang, verb, 5)); ok {
+			return conjugateOne(cmd, t, cfg, opts, choice)
+		}
+		return nil
 	}
 
 	fmt.Printf("Verb Conjugations for: %s\n", verb)
-	displayConjugations(conjugations)
+	if !hasConjugateFilters(cmd) {
+		displayConjugations(verbLang, verb, conjugations, cfg)
+		return nil
+	}
+
+	// Print only the slice selected by the filter flags
+	if voseo {
+		conjugations = conjugation.AddVoseo(verb, conjugations)
+	}
+	translator.DisplayConjugationTable(verbLang, conjugations, opts)
+	return nil
 }
 
 func main() {
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"tr/internal/vocab"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// reviewCount limits the cards in a review session; 0 reviews all due cards
+var reviewCount int
+
+// statsDays is the number of days of upcoming reviews shown by review stats
+const statsDays = 7
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review the words that are due, with spaced repetition",
+	Long: `Review the words you looked up that are due today. Words you answer
+correctly come back after longer and longer intervals (SM-2); words you miss
+come back tomorrow. Press Ctrl+D to stop early.`,
+	Args: cobra.NoArgs,
+	Run:  runReview,
+}
+
+var reviewStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show retention and the upcoming review load",
+	Args:  cobra.NoArgs,
+	Run:   runReviewStats,
+}
+
+func init() {
+	reviewCmd.Flags().IntVarP(&reviewCount, "count", "n", 0, "Maximum number of cards to review (default all due)")
+	reviewCmd.AddCommand(reviewStatsCmd)
+	rootCmd.AddCommand(reviewCmd)
+}
+
+func runReview(cmd *cobra.Command, args []string) {
+	store := vocab.Open()
+	if store.Len() == 0 {
+		fmt.Println("No words to review yet. Translate some words first, e.g. tr hablar")
+		return
+	}
+	if len(store.Due()) == 0 {
+		fmt.Println("Nothing is due. Run 'tr review stats' to see what's coming up.")
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	ask := func(prompt string) (string, bool) {
+		fmt.Print(prompt)
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	}
+
+	correct, asked := vocab.Review(store, ask, os.Stdout, reviewCount, rand.New(rand.NewSource(time.Now().UnixNano())))
+	fmt.Printf("\n%s\n", color.New(color.FgCyan, color.Bold).Sprintf("Score: %d/%d", correct, asked))
+	if left := len(store.Due()); left > 0 {
+		fmt.Printf("%d cards are still due.\n", left)
+	}
+}
+
+func runReviewStats(cmd *cobra.Command, args []string) {
+	stats := vocab.Open().Stats(statsDays)
+	titleColor := color.New(color.FgCyan, color.Bold)
+	labelColor := color.New(color.FgGreen)
+
+	fmt.Println(titleColor.Sprint("Vocabulary"))
+	fmt.Printf("  %s %d (%d new, %d learning, %d mature)\n", labelColor.Sprint("Words:"), stats.Total, stats.New, stats.Learning, stats.Mature)
+	fmt.Printf("  %s %d\n", labelColor.Sprint("Due now:"), stats.Due)
+	if stats.Reviews > 0 {
+		fmt.Printf("  %s %.0f%% of %d reviews\n", labelColor.Sprint("Retention:"), stats.Retention*100, stats.Reviews)
+	} else {
+		fmt.Printf("  %s no reviews yet\n", labelColor.Sprint("Retention:"))
+	}
+
+	fmt.Println()
+	fmt.Println(titleColor.Sprint("Upcoming reviews"))
+	busiest := 0
+	for _, n := range stats.Upcoming {
+		busiest = max(busiest, n)
+	}
+	today := time.Now()
+	for day, n := range stats.Upcoming {
+		label := today.AddDate(0, 0, day).Format("Mon Jan 2")
+		switch day {
+		case 0:
+			label = "Later today"
+		case 1:
+			label = "Tomorrow"
+		}
+		bar := ""
+		if busiest > 0 {
+			bar = strings.Repeat("█", (n*20+busiest-1)/busiest)
+		}
+		fmt.Printf("  %-12s %3d %s\n", label, n, bar)
+	}
+}
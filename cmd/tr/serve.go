@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"tr/internal/server"
+	"tr/internal/translator"
+	"tr/pkg/tr"
+
+	"github.com/spf13/cobra"
+)
+
+// serveAddr is the address tr serve listens on
+var serveAddr string
+
+// shutdownTimeout is how long requests in flight get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve translations and conjugations as a local JSON API",
+	Long: `Serve the translator as a JSON API for other programs:
+
+  GET /translate?text=hola&from=es&to=en   (or direction=es2en)
+  GET /conjugate?verb=hablar&lang=es&tenses=present,future
+  GET /detect?text=hello&candidates=es,en
+  GET /health
+
+Parameters can also be POSTed as a JSON object. The same caches and
+settings as the command line are used. Stop with Ctrl+C; requests in flight
+are allowed to finish.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on, e.g. :8080")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg := loadConfig(cmd)
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	t := translator.New(tr.WithLogger(logger))
+	t.SetRegion(cfg.Region)
+	defer t.Flush()
+
+	srv := &http.Server{
+		Addr:              serveAddr,
+		Handler:           server.New(t, cfg.DefaultDirection, version, logger),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		logger.Printf("Listening on %s", serveAddr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	logger.Printf("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown failed: %w", err)
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"tr/internal/vocab"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// studyCount is the number of cards in a study session
+var studyCount int
+
+var studyCmd = &cobra.Command{
+	Use:   "study",
+	Short: "Quiz the words you looked up as flashcards",
+	Long: `Quiz the words you looked up as flashcards, in both directions.
+Type the translation and press Enter; accents and capitals don't matter.
+Press Ctrl+D to stop early.`,
+	Args: cobra.NoArgs,
+	Run:  runStudy,
+}
+
+func init() {
+	studyCmd.Flags().IntVarP(&studyCount, "count", "n", 10, "Number of cards to study")
+	rootCmd.AddCommand(studyCmd)
+}
+
+func runStudy(cmd *cobra.Command, args []string) {
+	store := vocab.Open()
+	if store.Len() == 0 {
+		fmt.Println("No words to study yet. Translate some words first, e.g. tr hablar")
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	ask := func(prompt string) (string, bool) {
+		fmt.Print(prompt)
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	}
+
+	correct, asked := vocab.Study(store, ask, os.Stdout, studyCount, rand.New(rand.NewSource(time.Now().UnixNano())))
+	fmt.Printf("\n%s\n", color.New(color.FgCyan, color.Bold).Sprintf("Score: %d/%d", correct, asked))
+}
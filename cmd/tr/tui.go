@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"tr/internal/translator"
+	"tr/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Translate in a full-screen interface",
+	Long: `Translate in a full-screen interface with an input bar, a scrolling
+results pane, a conjugation panel and the history of the session.
+
+Type a word and press Enter. Tab moves between the input, the conjugation
+panel and the history; in the conjugation panel ←/→ switch tenses, and
+Enter on a history entry looks it up again. Ctrl+T toggles the direction,
+as do the toggle, auto and direction commands. Esc or quit exits.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runTUI,
+}
+
+func init() {
+	tuiCmd.Flags().StringVarP(&direction, "direction", "d", "", "Translation direction as <from>2<to>, e.g. es2en, or auto")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	cfg := loadConfig(cmd)
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+	defer t.Flush()
+
+	if err := tui.New(t, cfg).Run(); err != nil {
+		return fmt.Errorf("tui failed: %w", err)
+	}
+	return nil
+}
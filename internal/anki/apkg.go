@@ -0,0 +1,234 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure Go driver, registered as "sqlite"
+)
+
+// modelID is fixed so every export shares one note type in Anki
+const modelID = 1718806453000
+
+// schema creates the tables of an Anki collection (schema version 11), the
+// format .apkg files are read in by every Anki version
+const schema = `
+CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null, models text not null, decks text not null, dconf text not null, tags text not null);
+CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null, flags integer not null, data text not null);
+CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null, mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null, ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null, odue integer not null, odid integer not null, flags integer not null, data text not null);
+CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null, ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null, type integer not null);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn on notes (usn);
+CREATE INDEX ix_cards_usn on cards (usn);
+CREATE INDEX ix_revlog_usn on revlog (usn);
+CREATE INDEX ix_cards_nid on cards (nid);
+CREATE INDEX ix_cards_sched on cards (did, queue, due);
+CREATE INDEX ix_revlog_cid on revlog (cid);
+CREATE INDEX ix_notes_csum on notes (csum);
+`
+
+// WriteAPKG writes cards as new cards of an Anki deck package at path
+func WriteAPKG(path, deck string, cards []Card, now time.Time) error {
+	tmp, err := os.MkdirTemp("", "tr-anki")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	collection := filepath.Join(tmp, "collection.anki2")
+	if err := writeCollection(collection, deck, cards, now); err != nil {
+		return err
+	}
+	return writeZip(path, collection)
+}
+
+// writeCollection creates the SQLite collection holding the deck
+func writeCollection(path, deck string, cards []Card, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	deckID := deckID(deck)
+	models, decks, conf, err := collectionJSON(deck, deckID, len(cards), now)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to write collection: %w", err)
+	}
+	defer tx.Rollback()
+
+	ms, secs := now.UnixMilli(), now.Unix()
+	if _, err := tx.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		secs, ms, ms, conf, models, decks, defaultDeckConfig); err != nil {
+		return fmt.Errorf("failed to write collection: %w", err)
+	}
+
+	for i, card := range cards {
+		id := ms + int64(i) // Note and card IDs are creation times in milliseconds
+		front, back := field(card.Front), field(card.Back)
+		sortField := stripHTML(front)
+		if _, err := tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			id, card.GUID, modelID, secs, " "+strings.Join(card.Tags, " ")+" ",
+			front+"\x1f"+back, sortField, checksum(sortField)); err != nil {
+			return fmt.Errorf("failed to write note: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+			id, id, deckID, secs, i+1); err != nil {
+			return fmt.Errorf("failed to write card: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write collection: %w", err)
+	}
+	return nil
+}
+
+// collectionJSON builds the note type, deck and collection settings stored
+// as JSON in the col table
+func collectionJSON(deck string, deckID int64, cardCount int, now time.Time) (models, decks, conf string, err error) {
+	mid := strconv.FormatInt(modelID, 10)
+	did := strconv.FormatInt(deckID, 10)
+
+	model := map[string]any{
+		"id": modelID, "name": "tr", "type": 0, "mod": now.Unix(), "usn": -1,
+		"sortf": 0, "did": deckID, "tags": []string{}, "vers": []any{},
+		"flds": []map[string]any{
+			{"name": "Front", "ord": 0, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []any{}},
+			{"name": "Back", "ord": 1, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []any{}},
+		},
+		"tmpls": []map[string]any{{
+			"name": "Card 1", "ord": 0, "did": nil, "bqfmt": "", "bafmt": "",
+			"qfmt": "{{Front}}",
+			"afmt": "{{FrontSide}}\n\n<hr id=answer>\n\n{{Back}}",
+		}},
+		"req":       []any{[]any{0, "any", []int{0}}},
+		"css":       ".card {\n font-family: arial;\n font-size: 20px;\n text-align: center;\n color: black;\n background-color: white;\n}\n",
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+	}
+
+	newDeck := func(id int64, name string) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "desc": "", "mod": now.Unix(), "usn": -1,
+			"collapsed": false, "browserCollapsed": false, "dyn": 0, "conf": 1,
+			"extendNew": 10, "extendRev": 50,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+
+	settings := map[string]any{
+		"nextPos": cardCount + 1, "estTimes": true, "activeDecks": []int64{1}, "sortType": "noteFld",
+		"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": 1, "newBury": true,
+		"newSpread": 0, "dueCounts": true, "curModel": mid, "collapseTime": 1200,
+	}
+
+	for _, part := range []struct {
+		dst   *string
+		value any
+	}{
+		{&models, map[string]any{mid: model}},
+		{&decks, map[string]any{"1": newDeck(1, "Default"), did: newDeck(deckID, deck)}},
+		{&conf, settings},
+	} {
+		data, err := json.Marshal(part.value)
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to encode collection: %w", err)
+		}
+		*part.dst = string(data)
+	}
+	return models, decks, conf, nil
+}
+
+// defaultDeckConfig is Anki's default options group
+const defaultDeckConfig = `{"1": {"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+"new": {"delays": [1, 10], "ints": [1, 4, 7], "initialFactor": 2500, "order": 1, "perDay": 20, "bury": true, "separate": true},
+"rev": {"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500, "ivlFct": 1, "bury": true, "minSpace": 1},
+"lapse": {"delays": [10], "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0}}}`
+
+// deckID derives a stable deck ID from its name, so exports with the same
+// deck name land in the same Anki deck
+func deckID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64()>>23) + 1 // Positive and within a millisecond timestamp's range
+}
+
+// checksum is the note checksum Anki uses to find duplicates: the first 8
+// hex digits of the SHA-1 of the sort field
+func checksum(sortField string) int64 {
+	sum := sha1.Sum([]byte(sortField))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+// stripHTML removes tags from a field for sorting and duplicate checks
+func stripHTML(text string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range text {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// writeZip packs the collection and an empty media list into an .apkg
+func writeZip(path, collection string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	src, err := os.Open(collection)
+	if err != nil {
+		return fmt.Errorf("failed to read collection: %w", err)
+	}
+	defer src.Close()
+
+	w, err := zw.Create("collection.anki2")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	media, err := zw.Create("media")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	media.Write([]byte("{}"))
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,147 @@
+package anki
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"tr/internal/vocab"
+	"tr/pkg/lang"
+)
+
+// Card is one note to export, with its front and back already rendered
+// as HTML
+type Card struct {
+	GUID  string // Stable ID so importing again updates the note
+	Front string
+	Back  string
+	Tags  []string
+}
+
+// Templates are html/template sources for the two kinds of cards, so markup
+// in a template is kept and the values it substitutes are escaped. Vocabulary
+// templates receive a WordData and conjugation templates a FormData.
+type Templates struct {
+	WordFront string
+	WordBack  string
+	FormFront string
+	FormBack  string
+}
+
+// DefaultTemplates ask word → translation and infinitive + tense + person → form
+var DefaultTemplates = Templates{
+	WordFront: "{{.Word}}",
+	WordBack:  "{{.Translation}}",
+	FormFront: "{{.Verb}} ({{.Tense}}, {{.Person}})",
+	FormBack:  "{{.Form}}",
+}
+
+// WordData is the data available to vocabulary templates
+type WordData struct {
+	Word        string
+	Translation string
+	From        string // Language names, e.g. "Spanish"
+	To          string
+	IsVerb      bool
+}
+
+// FormData is the data available to conjugation templates
+type FormData struct {
+	Verb   string
+	Tense  string // Human-readable, e.g. "present subjunctive"
+	Mood   string
+	Person string
+	Form   string
+}
+
+// WordCards renders a card per vocabulary entry
+func WordCards(entries []*vocab.Entry, templates Templates) ([]Card, error) {
+	front, back, err := parse("word", templates.WordFront, templates.WordBack)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []Card
+	for _, e := range entries {
+		data := WordData{
+			Word:        e.Word,
+			Translation: e.Translation,
+			From:        lang.Name(e.From),
+			To:          lang.Name(e.To),
+			IsVerb:      e.IsVerb,
+		}
+		card, err := render(front, back, data, "word", e.From, e.To, e.Word)
+		if err != nil {
+			return nil, err
+		}
+		card.Tags = []string{"tr", "vocab", e.From + "-" + e.To}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// FormCards renders a card per conjugated form of each verb, limited to
+// tenses and persons, in the order given
+func FormCards(language string, verbs []string, tables map[string]map[string]map[string]string, tenses, persons []string, templates Templates) ([]Card, error) {
+	front, back, err := parse("conjugation", templates.FormFront, templates.FormBack)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []Card
+	for _, verb := range verbs {
+		for _, tense := range tenses {
+			for _, person := range persons {
+				form := tables[verb][tense][person]
+				if form == "" {
+					continue
+				}
+
+				data := FormData{
+					Verb:   verb,
+					Tense:  strings.ReplaceAll(tense, "_", " "),
+					Mood:   lang.Mood(tense),
+					Person: person,
+					Form:   form,
+				}
+				card, err := render(front, back, data, "form", language, verb, tense, person)
+				if err != nil {
+					return nil, err
+				}
+				card.Tags = []string{"tr", "conjugation", tense}
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards, nil
+}
+
+// parse compiles the front and back templates of a card kind
+func parse(kind, front, back string) (*template.Template, *template.Template, error) {
+	f, err := template.New(kind + " front").Option("missingkey=error").Parse(front)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s front template: %w", kind, err)
+	}
+	b, err := template.New(kind + " back").Option("missingkey=error").Parse(back)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s back template: %w", kind, err)
+	}
+	return f, b, nil
+}
+
+// render executes a card's templates and derives its GUID from key
+func render(front, back *template.Template, data any, key ...string) (Card, error) {
+	var f, b bytes.Buffer
+	if err := front.Execute(&f, data); err != nil {
+		return Card{}, fmt.Errorf("failed to render card: %w", err)
+	}
+	if err := back.Execute(&b, data); err != nil {
+		return Card{}, fmt.Errorf("failed to render card: %w", err)
+	}
+
+	sum := sha1.Sum([]byte(strings.Join(key, "\x1f")))
+	return Card{GUID: hex.EncodeToString(sum[:])[:10], Front: f.String(), Back: b.String()}, nil
+}
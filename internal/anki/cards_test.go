@@ -0,0 +1,57 @@
+package anki
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"tr/internal/vocab"
+)
+
+func TestTemplatesKeepMarkup(t *testing.T) {
+	templates := DefaultTemplates
+	templates.FormBack = "<b>{{.Form}}</b><br><i>{{.Mood}}</i>"
+
+	tables := map[string]map[string]map[string]string{
+		"hablar": {"present": {"yo": "hablo <3"}},
+	}
+	cards, err := FormCards("es", []string{"hablar"}, tables, []string{"present"}, []string{"yo"}, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("FormCards = %d cards, want 1", len(cards))
+	}
+
+	if want := "<b>hablo &lt;3</b><br><i>indicative</i>"; field(cards[0].Back) != want {
+		t.Errorf("back = %q, want %q", field(cards[0].Back), want)
+	}
+	if want := "hablar (present, yo)"; field(cards[0].Front) != want {
+		t.Errorf("front = %q, want %q", field(cards[0].Front), want)
+	}
+}
+
+func TestValuesEscaped(t *testing.T) {
+	entries := []*vocab.Entry{{Word: "rock & roll", Translation: "<script>x</script>\nline", From: "en", To: "es"}}
+	cards, err := WordCards(entries, DefaultTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTSV(&buf, cards); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	fields := strings.Split(lines[len(lines)-1], "\t")
+	if len(fields) != 4 {
+		t.Fatalf("TSV row = %q, want 4 fields", lines[len(lines)-1])
+	}
+
+	if want := "rock &amp; roll"; fields[1] != want {
+		t.Errorf("front = %q, want %q", fields[1], want)
+	}
+	if want := "&lt;script&gt;x&lt;/script&gt;<br>line"; fields[2] != want {
+		t.Errorf("back = %q, want %q", fields[2], want)
+	}
+}
@@ -0,0 +1,29 @@
+package anki
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// lineBreaks turns newlines into HTML and tabs into spaces, since Anki
+// fields are HTML and TSV fields can't contain tabs
+var lineBreaks = strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\t", " ")
+
+// field converts rendered HTML to an Anki field. Templates already escape
+// the values they substitute, so markup is left as it is.
+func field(text string) string {
+	return lineBreaks.Replace(text)
+}
+
+// WriteTSV writes cards as a tab-separated file for Anki's File > Import,
+// with the GUID, front, back and tags as columns
+func WriteTSV(w io.Writer, cards []Card) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("#separator:tab\n#html:true\n#guid column:1\n#tags column:4\n")
+	for _, card := range cards {
+		fields := []string{card.GUID, field(card.Front), field(card.Back), strings.Join(card.Tags, " ")}
+		bw.WriteString(strings.Join(fields, "\t") + "\n")
+	}
+	return bw.Flush()
+}
@@ -3,16 +3,20 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+
+	"tr/pkg/lang"
 )
 
 // Config represents the application configuration
 type Config struct {
-	DefaultDirection string   `json:"default_direction"` // "es2en" or "en2es"
+	DefaultDirection string   `json:"default_direction"` // "<from>2<to>" such as "es2en" or "fr2es", or "auto"
 	DefaultTenses    []string `json:"default_tenses"`    // Which tenses to show by default
 	ShowAllTenses    bool     `json:"show_all_tenses"`   // Show all available tenses
+	Region           string   `json:"region"`            // Spanish variety: "spain", "mexico" or "rioplatense"
 }
 
 // DefaultConfig returns the default configuration
@@ -21,40 +25,23 @@ func DefaultConfig() *Config {
 		DefaultDirection: "es2en",
 		DefaultTenses:    []string{"present", "preterite"},
 		ShowAllTenses:    false,
+		Region:           lang.DefaultRegion,
 	}
 }
 
-// LoadConfig loads configuration from file or creates default
-func LoadConfig() (*Config, error) {
-	configPath := getConfigPath()
-
+// LoadConfig loads the effective configuration from all layers (see
+// Resolve), creating the user config file with defaults if it doesn't exist
+func LoadConfig(overrides ...Override) (*Config, error) {
 	// If config file doesn't exist, create default
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		config := DefaultConfig()
-		if err := config.Save(); err != nil {
-			return config, fmt.Errorf("failed to save default config: %w", err)
+	if _, err := os.Stat(getConfigPath()); os.IsNotExist(err) {
+		if err := DefaultConfig().Save(); err != nil {
+			res, _ := Resolve(overrides...)
+			return res.Config, fmt.Errorf("failed to save default config: %w", err)
 		}
-		return config, nil
-	}
-
-	// Load existing config
-	file, err := os.Open(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %w", err)
 	}
-	defer file.Close()
 
-	data, err := io.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
-	return &config, nil
+	res, err := Resolve(overrides...)
+	return res.Config, err
 }
 
 // Save saves the configuration to file
@@ -78,8 +65,13 @@ func (c *Config) Save() error {
 	return nil
 }
 
-// getConfigPath returns the path to the configuration file
+// getConfigPath returns the path to the configuration file, inside
+// $XDG_CONFIG_HOME when it is set
 func getConfigPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "tr", "config.json")
+	}
+
 	// Use user's home directory for config
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -90,20 +82,148 @@ func getConfigPath() string {
 	return filepath.Join(homeDir, ".config", "tr", "config.json")
 }
 
-// GetAvailableTenses returns all available tenses for conjugation
+// Dir returns the directory holding tr's configuration and data files
+func Dir() string {
+	return filepath.Dir(getConfigPath())
+}
+
+// GetAvailableTenses returns all available tenses for Spanish conjugation
 func GetAvailableTenses() []string {
-	return []string{
-		"present",
-		"preterite",
-		"imperfect",
-		"future",
-		"conditional",
-		"present_subjunctive",
-		"imperfect_subjunctive",
-		"present_perfect",
-		"pluperfect",
-		"future_perfect",
-		"conditional_perfect",
-		"present_perfect_subjunctive",
+	return lang.Tenses("es")
+}
+
+// Path returns the location of the user configuration file
+func Path() string {
+	return getConfigPath()
+}
+
+// Keys lists the configuration keys in display order
+var Keys = []string{"default_direction", "default_tenses", "show_all_tenses", "region"}
+
+// keyAliases maps short names accepted by Get and Set to their keys
+var keyAliases = map[string]string{
+	"direction": "default_direction",
+	"tenses":    "default_tenses",
+	"show_all":  "show_all_tenses",
+}
+
+// ResolveKey returns the configuration key for name, accepting short aliases
+// like "tenses"
+func ResolveKey(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if key, ok := keyAliases[name]; ok {
+		return key, nil
+	}
+	if slices.Contains(Keys, name) {
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown setting %q (valid: %s)", name, strings.Join(Keys, ", "))
+}
+
+// Get returns the value of a setting formatted as it would be passed to Set
+func (c *Config) Get(name string) (string, error) {
+	key, err := ResolveKey(name)
+	if err != nil {
+		return "", err
+	}
+
+	switch key {
+	case "default_direction":
+		return c.DefaultDirection, nil
+	case "default_tenses":
+		return strings.Join(c.DefaultTenses, ","), nil
+	case "region":
+		return c.Region, nil
+	default:
+		return formatBool(c.ShowAllTenses), nil
+	}
+}
+
+// Set validates value and assigns it to a setting. Tenses may be separated
+// by commas or spaces; booleans accept on/off, true/false and yes/no.
+func (c *Config) Set(name, value string) error {
+	key, err := ResolveKey(name)
+	if err != nil {
+		return err
+	}
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case "default_direction":
+		direction, err := parseDirection(value)
+		if err != nil {
+			return err
+		}
+		c.DefaultDirection = direction
+	case "default_tenses":
+		tenses, err := parseTenses(value)
+		if err != nil {
+			return err
+		}
+		c.DefaultTenses = tenses
+	case "region":
+		region, err := lang.ParseRegion(value)
+		if err != nil {
+			return err
+		}
+		c.Region = region
+	default:
+		show, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		c.ShowAllTenses = show
+	}
+
+	return nil
+}
+
+// parseDirection validates a direction such as "en2es" or "auto"
+func parseDirection(value string) (string, error) {
+	value = strings.ToLower(value)
+	if value == "auto" {
+		return value, nil
+	}
+	from, to, err := lang.ParseDirection(value)
+	if err != nil {
+		return "", err
+	}
+	return lang.Direction(from, to), nil
+}
+
+// parseTenses splits a tense list and checks every name against GetAvailableTenses
+func parseTenses(value string) ([]string, error) {
+	tenses := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(tenses) == 0 {
+		return nil, fmt.Errorf("at least one tense is required")
+	}
+
+	available := GetAvailableTenses()
+	for _, tense := range tenses {
+		if !slices.Contains(available, tense) {
+			return nil, fmt.Errorf("unknown tense %q (valid: %s)", tense, strings.Join(available, ", "))
+		}
+	}
+	return tenses, nil
+}
+
+// parseBool accepts the usual spellings of a yes/no setting
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes", "1":
+		return true, nil
+	case "off", "false", "no", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid value %q, expected on or off", value)
+}
+
+// formatBool renders a boolean setting as on/off
+func formatBool(b bool) string {
+	if b {
+		return "on"
 	}
+	return "off"
 }
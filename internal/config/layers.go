@@ -0,0 +1,242 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"tr/pkg/lexicon"
+)
+
+// Sources a setting can come from, lowest precedence first
+const (
+	SourceDefault = "default"
+	SourceUser    = "user config"
+	SourceProject = "project config"
+	SourceEnv     = "environment"
+	SourceFlag    = "flag"
+)
+
+// projectFile is the name of the per-project configuration file, looked up
+// in the working directory and its parents
+const projectFile = ".tr.json"
+
+// envPrefix starts the environment variable for each key, e.g. TR_DEFAULT_TENSES
+const envPrefix = "TR_"
+
+// Origin records where the effective value of a setting came from
+type Origin struct {
+	Source string // One of the Source constants
+	Name   string // File path, environment variable or flag that set it
+}
+
+// Override is a setting given on the command line
+type Override struct {
+	Key   string // Setting name or alias
+	Value string
+	Flag  string // Flag that provided it, e.g. "--direction"
+}
+
+// Resolution is the effective configuration with the origin of each setting
+type Resolution struct {
+	Config  *Config
+	Origins map[string]Origin // By key
+	Files   []string          // Configuration files that were read
+}
+
+// Resolve builds the configuration from its layers: defaults, the user
+// config file, a project .tr.json, TR_* environment variables and finally
+// the given flag overrides. Invalid settings are skipped and reported
+// together in the error, so the returned resolution is always usable.
+func Resolve(overrides ...Override) (*Resolution, error) {
+	res := newResolution()
+	var problems []error
+
+	problems = append(problems, res.applyFile(getConfigPath(), SourceUser)...)
+	if path := findProjectFile(); path != "" {
+		problems = append(problems, res.applyFile(path, SourceProject)...)
+	}
+
+	for _, key := range Keys {
+		name := EnvVar(key)
+		if value, ok := os.LookupEnv(name); ok {
+			problems = append(problems, res.apply(key, value, Origin{SourceEnv, name}))
+		}
+	}
+
+	for _, o := range overrides {
+		problems = append(problems, res.apply(o.Key, o.Value, Origin{SourceFlag, o.Flag}))
+	}
+
+	return res, errors.Join(problems...)
+}
+
+// LoadUserConfig loads only the defaults and the user config file. Use it
+// when the configuration is going to be saved, so values from other layers
+// are not written to the user's file.
+func LoadUserConfig() (*Config, error) {
+	res := newResolution()
+	return res.Config, errors.Join(res.applyFile(getConfigPath(), SourceUser)...)
+}
+
+// ProjectPath returns the project .tr.json in effect, or "" if there is none
+func ProjectPath() string {
+	return findProjectFile()
+}
+
+// EnvVar returns the environment variable that overrides a setting
+func EnvVar(key string) string {
+	return envPrefix + strings.ToUpper(key)
+}
+
+// newResolution starts a resolution from the defaults
+func newResolution() *Resolution {
+	res := &Resolution{
+		Config:  DefaultConfig(),
+		Origins: make(map[string]Origin),
+	}
+	for _, key := range Keys {
+		res.Origins[key] = Origin{Source: SourceDefault}
+	}
+	return res
+}
+
+// apply sets one value through Config.Set and records its origin
+func (res *Resolution) apply(key, value string, origin Origin) error {
+	resolved, _ := ResolveKey(key)
+	if err := res.Config.Set(key, value); err != nil {
+		if origin.Source == SourceUser || origin.Source == SourceProject {
+			return fmt.Errorf("%s: %s: %w", origin.Name, resolved, err)
+		}
+		return fmt.Errorf("%s: %w", origin.Name, err)
+	}
+	res.Origins[resolved] = origin
+	return nil
+}
+
+// applyFile applies the settings in a JSON config file. A missing file is
+// not an error. Unknown keys and values of the wrong type are reported with
+// the file name so typos are easy to find.
+func (res *Resolution) applyFile(path, source string) []error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("failed to read config file: %w", err)}
+	}
+	res.Files = append(res.Files, path)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []error{fmt.Errorf("%s: failed to parse config file: %w", path, err)}
+	}
+
+	origin := Origin{Source: source, Name: path}
+	var problems []error
+	for _, key := range sortedKeys(raw) {
+		value, err := decodeValue(key, raw[key])
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if err := res.apply(key, value, origin); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return problems
+}
+
+// decodeValue checks the JSON type of a setting and returns it in the form
+// Config.Set accepts
+func decodeValue(key string, raw json.RawMessage) (string, error) {
+	if !slices.Contains(Keys, key) {
+		if closest := closestKey(key); closest != "" {
+			return "", fmt.Errorf("unknown setting %q, did you mean %q?", key, closest)
+		}
+		return "", fmt.Errorf("unknown setting %q (valid: %s)", key, strings.Join(Keys, ", "))
+	}
+
+	switch key {
+	case "default_direction":
+		var direction string
+		if err := json.Unmarshal(raw, &direction); err != nil {
+			return "", fmt.Errorf("%s must be a string such as \"es2en\"", key)
+		}
+		return direction, nil
+	case "region":
+		var region string
+		if err := json.Unmarshal(raw, &region); err != nil {
+			return "", fmt.Errorf("%s must be a string such as \"mexico\"", key)
+		}
+		return region, nil
+	case "default_tenses":
+		var tenses []string
+		if err := json.Unmarshal(raw, &tenses); err != nil {
+			return "", fmt.Errorf("%s must be a list of tense names such as [\"present\"]", key)
+		}
+		return strings.Join(tenses, ","), nil
+	default:
+		var show bool
+		if err := json.Unmarshal(raw, &show); err != nil {
+			return "", fmt.Errorf("%s must be true or false", key)
+		}
+		return formatBool(show), nil
+	}
+}
+
+// closestKey returns the key within a few typos of name, or ""
+func closestKey(name string) string {
+	best, bestDistance := "", 4
+	for _, key := range Keys {
+		if d := lexicon.Distance(strings.ToLower(name), key); d < bestDistance {
+			best, bestDistance = key, d
+		}
+	}
+	return best
+}
+
+// sortedKeys returns the keys of a decoded file in Keys order, followed by
+// any unknown keys
+func sortedKeys(raw map[string]json.RawMessage) []string {
+	var keys, unknown []string
+	for _, key := range Keys {
+		if _, ok := raw[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	for key := range raw {
+		if !slices.Contains(Keys, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return append(keys, unknown...)
+}
+
+// findProjectFile returns the nearest .tr.json in the working directory or
+// its parents, or "" if there is none
+func findProjectFile() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, projectFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
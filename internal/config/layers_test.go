@@ -0,0 +1,269 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// layers sets up a user config directory, a project directory to work in
+// and a clean TR_* environment. Empty files aren't written.
+type layers struct {
+	user    string // Contents of the user config.json
+	project string // Contents of .tr.json
+	env     map[string]string
+}
+
+// setup applies the layers and returns the user and project file paths
+func (l layers) setup(t *testing.T) (userPath, projectPath string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	for _, key := range Keys {
+		t.Setenv(EnvVar(key), "")
+		os.Unsetenv(EnvVar(key))
+	}
+	for name, value := range l.env {
+		t.Setenv(name, value)
+	}
+
+	userPath = filepath.Join(home, "xdg", "tr", "config.json")
+	if l.user != "" {
+		writeFile(t, userPath, l.user)
+	}
+
+	// The project file is found from a subdirectory of the project
+	project := filepath.Join(t.TempDir(), "project")
+	projectPath = filepath.Join(project, projectFile)
+	if l.project != "" {
+		writeFile(t, projectPath, l.project)
+	}
+	work := filepath.Join(project, "src")
+	if err := os.MkdirAll(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(work)
+
+	return userPath, projectPath
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	tests := []struct {
+		name      string
+		layers    layers
+		overrides []Override
+		want      Config
+		origins   map[string]Origin // By key; unlisted keys are defaults, files are named by the test
+	}{
+		{
+			name: "defaults",
+			want: *DefaultConfig(),
+		},
+		{
+			name:    "user file",
+			layers:  layers{user: `{"default_direction": "en2es", "region": "mexico"}`},
+			want:    Config{DefaultDirection: "en2es", DefaultTenses: []string{"present", "preterite"}, Region: "mexico"},
+			origins: map[string]Origin{"default_direction": {Source: SourceUser}, "region": {Source: SourceUser}},
+		},
+		{
+			name: "project over user",
+			layers: layers{
+				user:    `{"default_direction": "en2es", "show_all_tenses": true}`,
+				project: `{"default_direction": "fr2es", "default_tenses": ["future"]}`,
+			},
+			want: Config{DefaultDirection: "fr2es", DefaultTenses: []string{"future"}, ShowAllTenses: true, Region: "spain"},
+			origins: map[string]Origin{
+				"default_direction": {Source: SourceProject},
+				"default_tenses":    {Source: SourceProject},
+				"show_all_tenses":   {Source: SourceUser},
+			},
+		},
+		{
+			name: "environment over files",
+			layers: layers{
+				user:    `{"region": "mexico"}`,
+				project: `{"default_direction": "fr2es"}`,
+				env:     map[string]string{"TR_DEFAULT_DIRECTION": "auto", "TR_SHOW_ALL_TENSES": "yes"},
+			},
+			want: Config{DefaultDirection: "auto", DefaultTenses: []string{"present", "preterite"}, ShowAllTenses: true, Region: "mexico"},
+			origins: map[string]Origin{
+				"default_direction": {SourceEnv, "TR_DEFAULT_DIRECTION"},
+				"show_all_tenses":   {SourceEnv, "TR_SHOW_ALL_TENSES"},
+				"region":            {Source: SourceUser},
+			},
+		},
+		{
+			name: "flags over everything",
+			layers: layers{
+				project: `{"default_tenses": ["future"]}`,
+				env:     map[string]string{"TR_DEFAULT_TENSES": "imperfect", "TR_REGION": "rioplatense"},
+			},
+			overrides: []Override{
+				{Key: "tenses", Value: "present,future", Flag: "--tenses"},
+				{Key: "direction", Value: "es2fr", Flag: "--direction"},
+			},
+			want: Config{DefaultDirection: "es2fr", DefaultTenses: []string{"present", "future"}, Region: "rioplatense"},
+			origins: map[string]Origin{
+				"default_direction": {SourceFlag, "--direction"},
+				"default_tenses":    {SourceFlag, "--tenses"},
+				"region":            {SourceEnv, "TR_REGION"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userPath, projectPath := tt.layers.setup(t)
+
+			res, err := Resolve(tt.overrides...)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+
+			got := res.Config
+			if got.DefaultDirection != tt.want.DefaultDirection || !slices.Equal(got.DefaultTenses, tt.want.DefaultTenses) ||
+				got.ShowAllTenses != tt.want.ShowAllTenses || got.Region != tt.want.Region {
+				t.Errorf("config = %+v, want %+v", *got, tt.want)
+			}
+
+			// Origins name the file, variable or flag each value came from
+			for _, key := range Keys {
+				want, ok := tt.origins[key]
+				switch {
+				case !ok:
+					want = Origin{Source: SourceDefault}
+				case want.Source == SourceUser:
+					want.Name = userPath
+				case want.Source == SourceProject:
+					want.Name = projectPath
+				}
+				if got := res.Origins[key]; got != want {
+					t.Errorf("origin of %s = %+v, want %+v", key, got, want)
+				}
+			}
+
+			var files []string
+			if tt.layers.user != "" {
+				files = append(files, userPath)
+			}
+			if tt.layers.project != "" {
+				files = append(files, projectPath)
+			}
+			if !slices.Equal(res.Files, files) {
+				t.Errorf("files = %v, want %v", res.Files, files)
+			}
+		})
+	}
+}
+
+func TestResolveInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		layers    layers
+		overrides []Override
+		errors    []string // Substrings of the error
+	}{
+		{
+			name:   "unknown key with a suggestion",
+			layers: layers{user: `{"defualt_tenses": ["future"]}`},
+			errors: []string{"config.json", `unknown setting "defualt_tenses", did you mean "default_tenses"?`},
+		},
+		{
+			name:   "unknown key without a suggestion",
+			layers: layers{project: `{"colour": "blue"}`},
+			errors: []string{".tr.json", `unknown setting "colour" (valid: `},
+		},
+		{
+			name:   "wrong type",
+			layers: layers{user: `{"show_all_tenses": "maybe"}`},
+			errors: []string{"show_all_tenses must be true or false"},
+		},
+		{
+			name:   "invalid value in a file",
+			layers: layers{project: `{"region": "narnia"}`},
+			errors: []string{".tr.json: region: ", `unsupported region "narnia"`},
+		},
+		{
+			name:   "invalid environment variable",
+			layers: layers{env: map[string]string{"TR_DEFAULT_TENSES": "present,someday"}},
+			errors: []string{"TR_DEFAULT_TENSES: ", `unknown tense "someday"`},
+		},
+		{
+			name:      "invalid flag",
+			overrides: []Override{{Key: "direction", Value: "xx2yy", Flag: "--direction"}},
+			errors:    []string{"--direction: "},
+		},
+		{
+			name:   "malformed file",
+			layers: layers{user: `{"region": `},
+			errors: []string{"failed to parse config file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.layers.setup(t)
+
+			res, err := Resolve(tt.overrides...)
+			if err == nil {
+				t.Fatal("Resolve succeeded, want an error")
+			}
+			for _, want := range tt.errors {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't contain %q", err, want)
+				}
+			}
+
+			// The invalid setting is ignored and the rest stays usable
+			def := DefaultConfig()
+			if got := res.Config; got.DefaultDirection != def.DefaultDirection || !slices.Equal(got.DefaultTenses, def.DefaultTenses) ||
+				got.ShowAllTenses != def.ShowAllTenses || got.Region != def.Region {
+				t.Errorf("config = %+v, want the defaults", *got)
+			}
+			for key, origin := range res.Origins {
+				if origin.Source != SourceDefault {
+					t.Errorf("origin of %s = %+v, want default", key, origin)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveSkipsOnlyInvalidSettings(t *testing.T) {
+	(layers{user: `{"region": "mexico", "default_direction": "zz", "default_tenses": ["future", "later"]}`}).setup(t)
+
+	res, err := Resolve(Override{Key: "show_all_tenses", Value: "on", Flag: "--all"})
+	if err == nil {
+		t.Fatal("Resolve succeeded, want errors for default_direction and default_tenses")
+	}
+	for _, want := range []string{"default_direction", `unknown tense "later"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %s", err, want)
+		}
+	}
+
+	if res.Config.Region != "mexico" || !res.Config.ShowAllTenses {
+		t.Errorf("valid settings not applied: %+v", *res.Config)
+	}
+	if res.Config.DefaultDirection != "es2en" || !slices.Equal(res.Config.DefaultTenses, []string{"present", "preterite"}) {
+		t.Errorf("invalid settings not ignored: %+v", *res.Config)
+	}
+	if res.Origins["region"].Source != SourceUser || res.Origins["show_all_tenses"].Source != SourceFlag ||
+		res.Origins["default_direction"].Source != SourceDefault {
+		t.Errorf("origins = %+v", res.Origins)
+	}
+}
@@ -0,0 +1,161 @@
+package drill
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+
+	"github.com/fatih/color"
+)
+
+// Result is the grade of a typed form
+type Result int
+
+const (
+	Wrong         Result = iota
+	MissingAccent        // Right letters, missing or misplaced accents
+	Correct
+)
+
+// Question asks for one cell of a conjugation table
+type Question struct {
+	Verb   string
+	Tense  string
+	Person string
+	Answer string
+}
+
+// Prompt returns the question shown to the user in a region, e.g.
+// "tener, preterite, yo: "
+func (q Question) Prompt(region string) string {
+	return fmt.Sprintf("%s, %s, %s: ", q.Verb, strings.ReplaceAll(q.Tense, "_", " "), personLabel(q.Tense, q.Person, region))
+}
+
+// personLabel names the person asked for. The imperative's third person
+// rows, the third and last of the region's persons, hold the usted and
+// ustedes forms.
+func personLabel(tense, person, region string) string {
+	if tense != "imperative" {
+		return person
+	}
+	persons := lang.RegionPersons("es", region)
+	switch slices.Index(persons, person) {
+	case 2:
+		return "usted"
+	case len(persons) - 1:
+		return "ustedes"
+	}
+	return person
+}
+
+// Grade compares a typed form with the expected one, ignoring case and
+// surrounding space. A form that only differs in accents is MissingAccent.
+// Forms with alternatives ("hubiera/hubiese") accept any of them.
+func Grade(answer, expected string) Result {
+	given := strings.ToLower(strings.Join(strings.Fields(answer), " "))
+	if given == "" {
+		return Wrong
+	}
+
+	result := Wrong
+	for _, option := range strings.FieldsFunc(expected, func(r rune) bool { return r == '/' || r == ',' }) {
+		option = strings.ToLower(strings.TrimSpace(option))
+		switch {
+		case option == given:
+			return Correct
+		case lexicon.Fold(option) == lexicon.Fold(given):
+			result = MissingAccent
+		}
+	}
+	return result
+}
+
+// Pick chooses a random cell among the given verbs' tables, limited to
+// tenses and persons. It reports false if none of the tables has such a cell.
+func Pick(verbs map[string]map[string]map[string]string, tenses, persons []string, rnd *rand.Rand) (Question, bool) {
+	var cells []Question
+	for _, verb := range sortedVerbs(verbs) {
+		for _, tense := range tenses {
+			for _, person := range persons {
+				if form := verbs[verb][tense][person]; form != "" {
+					cells = append(cells, Question{Verb: verb, Tense: tense, Person: person, Answer: form})
+				}
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return Question{}, false
+	}
+	return cells[rnd.Intn(len(cells))], true
+}
+
+// Run asks count random forms for the persons of a Spanish region (or until
+// the user stops), gives feedback on each and records the results in stats.
+// It returns the number of correct answers and questions asked.
+func Run(verbs map[string]map[string]map[string]string, tenses []string, region string, ask func(prompt string) (string, bool), out io.Writer, count int, rnd *rand.Rand, stats *Stats) (correct, asked int) {
+	persons := lang.RegionPersons("es", region)
+	var last Question
+	for asked < count {
+		q, ok := Pick(verbs, tenses, persons, rnd)
+		if !ok {
+			break
+		}
+		// Avoid asking the same cell twice in a row when there's a choice
+		for tries := 0; q == last && tries < 3; tries++ {
+			q, _ = Pick(verbs, tenses, persons, rnd)
+		}
+		last = q
+
+		answer, ok := ask(q.Prompt(region))
+		if !ok {
+			break
+		}
+
+		asked++
+		result := Grade(answer, q.Answer)
+		stats.Record(q.Tense, result)
+		switch result {
+		case Correct:
+			correct++
+			fmt.Fprintln(out, color.New(color.FgGreen).Sprint("✓ Correct!"))
+		case MissingAccent:
+			fmt.Fprintln(out, color.New(color.FgYellow).Sprintf("~ Missing accent: %s", q.Answer))
+		default:
+			fmt.Fprintln(out, color.New(color.FgRed).Sprintf("✗ The answer is: %s", q.Answer))
+		}
+	}
+
+	stats.Save()
+	return correct, asked
+}
+
+// Tenses returns the tenses of available that at least one verb table has,
+// keeping the order of available
+func Tenses(verbs map[string]map[string]map[string]string, available []string) []string {
+	var tenses []string
+	for _, tense := range available {
+		for _, conjugations := range verbs {
+			if len(conjugations[tense]) > 0 {
+				tenses = append(tenses, tense)
+				break
+			}
+		}
+	}
+	return tenses
+}
+
+// sortedVerbs returns the verbs in a stable order so a seeded drill repeats
+func sortedVerbs(verbs map[string]map[string]map[string]string) []string {
+	names := make([]string, 0, len(verbs))
+	for verb := range verbs {
+		names = append(names, verb)
+	}
+	sort.Strings(names)
+	return names
+}
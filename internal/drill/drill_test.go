@@ -0,0 +1,66 @@
+package drill
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"tr/pkg/lang"
+)
+
+func TestPromptPersonLabels(t *testing.T) {
+	tests := []struct {
+		region string
+		tense  string
+		person string
+		want   string
+	}{
+		{"spain", "imperative", "él/ella", "usted"},
+		{"spain", "imperative", "ellos", "ustedes"},
+		{"spain", "imperative", "vosotros", "vosotros"},
+		{"mexico", "imperative", "ellos/ustedes", "ustedes"},
+		{"mexico", "imperative", "tú", "tú"},
+		{"rioplatense", "imperative", "vos", "vos"},
+		{"rioplatense", "imperative", "ellos/ustedes", "ustedes"},
+		{"mexico", "present", "ellos/ustedes", "ellos/ustedes"},
+		{"spain", "present_subjunctive", "él/ella", "él/ella"},
+	}
+
+	for _, tt := range tests {
+		persons := lang.RegionPersons("es", tt.region)
+		if !slices.Contains(persons, tt.person) {
+			t.Fatalf("%s persons %v lack %q", tt.region, persons, tt.person)
+		}
+
+		q := Question{Verb: "hablar", Tense: tt.tense, Person: tt.person}
+		want := "hablar, " + strings.ReplaceAll(tt.tense, "_", " ") + ", " + tt.want + ": "
+		if got := q.Prompt(tt.region); got != want {
+			t.Errorf("Prompt(%q) = %q, want %q", tt.region, got, want)
+		}
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected string
+		want     Result
+	}{
+		{"hablé", "hablé", Correct},
+		{"  Hablé ", "hablé", Correct},
+		{"hable", "hablé", MissingAccent},
+		{"háble", "hablé", MissingAccent}, // Misplaced accent
+		{"hablo", "hablé", Wrong},
+		{"", "hablé", Wrong},
+		{"hubiese", "hubiera/hubiese", Correct},
+		{"hubiesemos", "hubiéramos/hubiésemos", MissingAccent},
+		{"hubieramos", "hubiéramos, hubiésemos", MissingAccent},
+		{"tuviera", "hubiera/hubiese", Wrong},
+	}
+
+	for _, tt := range tests {
+		if got := Grade(tt.answer, tt.expected); got != tt.want {
+			t.Errorf("Grade(%q, %q) = %d, want %d", tt.answer, tt.expected, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package drill
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"tr/internal/config"
+)
+
+// TenseStats counts drill answers for one tense
+type TenseStats struct {
+	Correct       int `json:"correct"`
+	MissingAccent int `json:"missing_accent"`
+	Wrong         int `json:"wrong"`
+}
+
+// Total returns the number of answers
+func (t TenseStats) Total() int {
+	return t.Correct + t.MissingAccent + t.Wrong
+}
+
+// Accuracy returns the share of fully correct answers, 0 without answers
+func (t TenseStats) Accuracy() float64 {
+	if t.Total() == 0 {
+		return 0
+	}
+	return float64(t.Correct) / float64(t.Total())
+}
+
+// Stats holds per-tense drill accuracy, persisted as JSON.
+// Stats with an empty path are kept in memory only.
+type Stats struct {
+	path   string
+	Tenses map[string]*TenseStats
+}
+
+// LoadStats reads the statistics from ~/.config/tr/drill-stats.json,
+// starting empty if the file is missing or unreadable
+func LoadStats() *Stats {
+	s := &Stats{
+		path:   filepath.Join(config.Dir(), "drill-stats.json"),
+		Tenses: make(map[string]*TenseStats),
+	}
+	if data, err := os.ReadFile(s.path); err == nil {
+		json.Unmarshal(data, &s.Tenses)
+	}
+	return s
+}
+
+// Record counts an answer for a tense
+func (s *Stats) Record(tense string, result Result) {
+	t := s.Tenses[tense]
+	if t == nil {
+		t = &TenseStats{}
+		s.Tenses[tense] = t
+	}
+
+	switch result {
+	case Correct:
+		t.Correct++
+	case MissingAccent:
+		t.MissingAccent++
+	default:
+		t.Wrong++
+	}
+}
+
+// Save writes the statistics to disk
+func (s *Stats) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s.Tenses, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
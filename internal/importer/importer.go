@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"tr/internal/translator"
+	"tr/pkg/lang"
+)
+
+// Job is a word to translate, and conjugate if it is a verb
+type Job struct {
+	Word string
+	From string
+	To   string
+}
+
+// Result is the outcome of a job. A job failed if Err is set; a verb whose
+// conjugations couldn't be fetched still has its translation.
+type Result struct {
+	Job
+	Translation     *translator.TranslationResult
+	Cached          bool // Nothing had to be fetched
+	LowConfidence   bool // The translation looks like a failed lookup and wasn't cached
+	Conjugated      bool
+	ConjugationsErr error
+	Err             error
+}
+
+// Options control how fast the import talks to the web services
+type Options struct {
+	Workers int           // Concurrent lookups
+	Rate    float64       // Requests per second across all workers, 0 for no limit
+	Retries int           // Retries of a rate-limited request
+	Backoff time.Duration // Wait before the first retry, doubled for each next one
+}
+
+// DefaultOptions stay well within the free services' limits
+var DefaultOptions = Options{Workers: 4, Rate: 4, Retries: 3, Backoff: 2 * time.Second}
+
+// ReadWords reads one word or phrase per line. Blank lines and lines starting
+// with # are skipped, anything after a tab or " - " is ignored (so
+// "hablar - to speak" imports hablar), and duplicates are dropped.
+func ReadWords(r io.Reader) ([]string, error) {
+	var words []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line, _, _ = strings.Cut(line, "\t")
+		line, _, _ = strings.Cut(line, " - ")
+		word := strings.TrimSpace(line)
+		if word != "" && !seen[strings.ToLower(word)] {
+			seen[strings.ToLower(word)] = true
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+	return words, nil
+}
+
+// Run translates the jobs with a pool of workers, conjugating verbs in
+// languages with conjugation support, which fills the translator's caches.
+// progress is called after each job from a single goroutine. Results are
+// returned in job order.
+func Run(t translator.Translator, jobs []Job, opts Options, progress func(done, total int)) []Result {
+	workers := max(opts.Workers, 1)
+	limit := newLimiter(opts.Rate)
+	defer limit.stop()
+
+	cachedVerbs := t.CachedVerbs()
+	indexes := make(chan int)
+	done := make(chan int)
+	results := make([]Result, len(jobs))
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runJob(t, jobs[i], opts, limit, cachedVerbs)
+				done <- i
+			}
+		}()
+	}
+
+	go func() {
+		for i := range jobs {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(done)
+	}()
+
+	count := 0
+	for range done {
+		count++
+		if progress != nil {
+			progress(count, len(jobs))
+		}
+	}
+
+	t.Flush()
+	return results
+}
+
+// runJob translates one word and conjugates it if it is a verb
+func runJob(t translator.Translator, job Job, opts Options, limit *limiter, cachedVerbs []string) Result {
+	result := Result{Job: job, Cached: true}
+
+	if cached, ok := t.CachedTranslation(job.Word, job.From, job.To); ok {
+		result.Translation = cached
+	} else {
+		result.Cached = false
+		result.Err = retry(opts, limit, func() (err error) {
+			result.Translation, err = t.Translate(job.Word, job.From, job.To)
+			return err
+		})
+		if result.Err != nil {
+			return result
+		}
+		result.LowConfidence = translator.IsLowConfidence(result.Translation)
+	}
+
+	if !result.Translation.IsVerb || !lang.SupportsConjugation(job.From) {
+		return result
+	}
+
+	// Spanish conjugations come from SpanishDict, the others are offline
+	verb := strings.ToLower(job.Word)
+	if job.From != "es" || slices.Contains(cachedVerbs, verb) {
+		_, result.ConjugationsErr = t.GetConjugations(job.From, verb)
+	} else {
+		result.Cached = false
+		result.ConjugationsErr = retry(opts, limit, func() error {
+			conjugations, err := t.GetConjugations(job.From, verb)
+			if err == nil && len(conjugations) == 0 {
+				err = fmt.Errorf("no conjugations found")
+			}
+			return err
+		})
+	}
+	result.Conjugated = result.ConjugationsErr == nil
+	return result
+}
+
+// retry runs a request within the rate limit, waiting and trying again while
+// the service reports that it is rate limited
+func retry(opts Options, limit *limiter, request func() error) error {
+	backoff := opts.Backoff
+	for attempt := 0; ; attempt++ {
+		limit.wait()
+		err := request()
+		if !errors.Is(err, translator.ErrRateLimited) || attempt >= opts.Retries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// limiter spaces requests evenly to stay under a rate
+type limiter struct {
+	ticker *time.Ticker
+}
+
+// newLimiter returns a limiter for rate requests per second, or one that
+// never waits if rate is not positive
+func newLimiter(rate float64) *limiter {
+	if rate <= 0 {
+		return &limiter{}
+	}
+	return &limiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / rate))}
+}
+
+// wait blocks until the next request may be made
+func (l *limiter) wait() {
+	if l.ticker != nil {
+		<-l.ticker.C
+	}
+}
+
+// stop releases the limiter's ticker
+func (l *limiter) stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
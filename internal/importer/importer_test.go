@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"errors"
+	"testing"
+
+	"tr/internal/translator"
+	"tr/pkg/conjugation"
+)
+
+// stubTranslator knows a few words and conjugates hablar
+type stubTranslator struct{}
+
+func (stubTranslator) Translate(text, from, to string) (*translator.TranslationResult, error) {
+	switch text {
+	case "hablar":
+		return &translator.TranslationResult{OriginalText: text, Translation: "to speak", IsVerb: true, Confidence: 1}, nil
+	case "casa":
+		return &translator.TranslationResult{OriginalText: text, Translation: "house", Confidence: 1}, nil
+	case "xyzzy":
+		return &translator.TranslationResult{OriginalText: text, Translation: "xyzzy", Confidence: 0.1}, nil
+	}
+	return nil, errors.New("service unavailable")
+}
+
+func (stubTranslator) GetConjugations(language, verb string) (conjugation.Table, error) {
+	return conjugation.Table{"present": {"yo": "hablo"}}, nil
+}
+
+func (stubTranslator) CachedVerbs() []string { return nil }
+
+func (stubTranslator) CachedTranslation(text, from, to string) (*translator.TranslationResult, bool) {
+	return nil, false
+}
+
+func (stubTranslator) SetRegion(region string) {}
+
+func (stubTranslator) Flush() {}
+
+func TestRun(t *testing.T) {
+	var jobs []Job
+	for _, word := range []string{"hablar", "casa", "xyzzy", "nada"} {
+		jobs = append(jobs, Job{Word: word, From: "es", To: "en"})
+	}
+
+	results := Run(stubTranslator{}, jobs, Options{Workers: 2}, nil)
+	if len(results) != len(jobs) {
+		t.Fatalf("Run returned %d results, want %d", len(results), len(jobs))
+	}
+
+	tests := []struct {
+		lowConfidence, conjugated, failed bool
+	}{
+		{false, true, false},  // hablar
+		{false, false, false}, // casa
+		{true, false, false},  // xyzzy
+		{false, false, true},  // nada
+	}
+	for i, tt := range tests {
+		r := results[i]
+		if r.Word != jobs[i].Word {
+			t.Errorf("result %d is for %q, want %q", i, r.Word, jobs[i].Word)
+		}
+		if r.LowConfidence != tt.lowConfidence || r.Conjugated != tt.conjugated || (r.Err != nil) != tt.failed {
+			t.Errorf("%s: low confidence %v, conjugated %v, err %v; want %v, %v, failed %v",
+				r.Word, r.LowConfidence, r.Conjugated, r.Err, tt.lowConfidence, tt.conjugated, tt.failed)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package lists
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"tr/internal/config"
+	"tr/internal/translator"
+)
+
+// Item is a word saved to a list
+type Item struct {
+	Word        string    `json:"word"`
+	Translation string    `json:"translation"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	IsVerb      bool      `json:"is_verb,omitempty"`
+	Added       time.Time `json:"added"`
+}
+
+// List is a named collection of words, e.g. "week 3" or "kitchen verbs".
+// List names double as tags for filtering conjugate, lookups and exports.
+type List struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Items   []Item    `json:"items"`
+}
+
+// Store holds all word lists, persisted as JSON.
+// A store with an empty path is kept in memory only.
+type Store struct {
+	path  string
+	lists []*List
+}
+
+// Open loads the word lists from ~/.config/tr/lists.json, starting empty
+// if the file is missing or unreadable
+func Open() *Store {
+	s := &Store{path: filepath.Join(config.Dir(), "lists.json")}
+	if data, err := os.ReadFile(s.path); err == nil {
+		json.Unmarshal(data, &s.lists)
+	}
+	return s
+}
+
+// Save writes the lists to disk
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.lists, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lists: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write lists: %w", err)
+	}
+	return nil
+}
+
+// Names returns the list names in alphabetical order
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.lists))
+	for _, l := range s.lists {
+		names = append(names, l.Name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names
+}
+
+// Get returns a list by name, ignoring case
+func (s *Store) Get(name string) (*List, bool) {
+	for _, l := range s.lists {
+		if strings.EqualFold(l.Name, strings.TrimSpace(name)) {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// Add saves a translation to a list, creating the list if needed. It
+// reports false if the word was already on the list; its translation is
+// updated either way.
+func (s *Store) Add(name string, result *translator.TranslationResult, from, to string, now time.Time) (bool, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, fmt.Errorf("list name is empty")
+	}
+
+	l, ok := s.Get(name)
+	if !ok {
+		l = &List{Name: name, Created: now}
+		s.lists = append(s.lists, l)
+	}
+
+	word := strings.TrimSpace(result.OriginalText)
+	for i := range l.Items {
+		item := &l.Items[i]
+		if item.From == from && item.To == to && strings.EqualFold(item.Word, word) {
+			item.Translation = result.Translation
+			return false, nil
+		}
+	}
+
+	l.Items = append(l.Items, Item{
+		Word:        word,
+		Translation: result.Translation,
+		From:        from,
+		To:          to,
+		IsVerb:      result.IsVerb,
+		Added:       now,
+	})
+	return true, nil
+}
+
+// Remove deletes words from a list, or the whole list if no words are
+// given. It returns the number of words removed.
+func (s *Store) Remove(name string, words ...string) (int, error) {
+	l, ok := s.Get(name)
+	if !ok {
+		return 0, fmt.Errorf("no list named %q", name)
+	}
+
+	if len(words) == 0 {
+		for i, other := range s.lists {
+			if other == l {
+				s.lists = append(s.lists[:i], s.lists[i+1:]...)
+				break
+			}
+		}
+		return len(l.Items), nil
+	}
+
+	removed := 0
+	kept := l.Items[:0]
+	for _, item := range l.Items {
+		if containsFold(words, item.Word) {
+			removed++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	l.Items = kept
+
+	if removed == 0 {
+		return 0, fmt.Errorf("none of those words are on %q", l.Name)
+	}
+	return removed, nil
+}
+
+// Tagged returns the items of the named lists. Words on several lists are
+// returned once.
+func (s *Store) Tagged(names ...string) ([]Item, error) {
+	var items []Item
+	seen := make(map[string]bool)
+	for _, name := range names {
+		l, ok := s.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("no list named %q", name)
+		}
+		for _, item := range l.Items {
+			key := item.From + ":" + item.To + ":" + strings.ToLower(item.Word)
+			if !seen[key] {
+				seen[key] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+// containsFold reports whether words contains word, ignoring case
+func containsFold(words []string, word string) bool {
+	for _, w := range words {
+		if strings.EqualFold(strings.TrimSpace(w), word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// Choose lists options numbered from 1 and waits for a single key press,
+// read from in with the terminal in raw mode. Callers that buffer stdin
+// pass their own reader so no input is lost. It returns the chosen option,
+// or false if another key was pressed or in is nil or stdin is not a
+// terminal, in which case the list is only printed.
+func Choose(in io.Reader, title string, options []string) (string, bool) {
+	if len(options) == 0 {
+		return "", false
+	}
+	options = options[:min(len(options), 9)] // One digit per option
+
+	titleColor := color.New(color.FgYellow)
+	numberColor := color.New(color.FgCyan, color.Bold)
+
+	fmt.Println(titleColor.Sprint(title))
+	for i, option := range options {
+		fmt.Printf("  %s %s\n", numberColor.Sprintf("%d)", i+1), option)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if in == nil || !term.IsTerminal(fd) {
+		return "", false
+	}
+
+	fmt.Printf("Press 1-%d to choose, any other key to skip: ", len(options))
+	key, err := readKey(fd, in)
+	fmt.Println()
+	if err != nil {
+		return "", false
+	}
+
+	if key >= '1' && int(key-'1') < len(options) {
+		return options[key-'1'], true
+	}
+	return "", false
+}
+
+// readKey reads one byte from in with the terminal in raw mode
+func readKey(fd int, in io.Reader) (byte, error) {
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return 0, err
+	}
+	defer term.Restore(fd, oldState)
+
+	buf := make([]byte, 1)
+	if _, err := io.ReadFull(in, buf); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
@@ -0,0 +1,144 @@
+package repl
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"tr/internal/config"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+)
+
+// commands lists the REPL commands offered by Tab completion
+var commands = []string{
+	"auto", "clear", "config", "direction", "exit", "expand",
+	"help", "history", "languages", "quit", "reset", "save", "set", "study", "tenses", "toggle",
+}
+
+// complete returns where the word before the cursor starts and its
+// completions: commands and looked-up words at the start of the line, verbs
+// and tense names after expand, directions after direction, settings and
+// their values after set, list names after save, and looked-up words
+// anywhere else
+func (r *REPL) complete(line []rune, pos int) (int, []string) {
+	start := pos
+	for start > 0 && !unicode.IsSpace(line[start-1]) {
+		start--
+	}
+	prefix := string(line[start:pos])
+	words := strings.Fields(string(line[:start]))
+
+	var options []string
+	switch {
+	case len(words) == 0:
+		options = append(options, commands...)
+		options = append(options, r.lookedUpWords()...)
+	case strings.EqualFold(words[0], "expand") && len(words) == 1:
+		options = r.knownVerbs()
+	case strings.EqualFold(words[0], "expand"):
+		options = config.GetAvailableTenses()
+	case strings.EqualFold(words[0], "direction") && len(words) == 1:
+		options = directions()
+	case strings.EqualFold(words[0], "set") && len(words) == 1:
+		options = config.Keys
+	case strings.EqualFold(words[0], "set") && len(words) == 2:
+		options = settingValues(words[1])
+	case strings.EqualFold(words[0], "save") && len(words) == 1:
+		options = r.lists.Names()
+	default:
+		options = r.lookedUpWords()
+	}
+
+	return start, matchPrefix(options, prefix)
+}
+
+// lookedUpWords returns single words from the history plus cached verbs
+func (r *REPL) lookedUpWords() []string {
+	words := r.translator.CachedVerbs()
+	for i := 0; i < r.history.Len(); i++ {
+		entry := r.history.At(i)
+		if !strings.Contains(entry, " ") && !isCommand(entry) {
+			words = append(words, entry)
+		}
+	}
+	return words
+}
+
+// knownVerbs returns cached verbs and the bundled verbs of the source language
+func (r *REPL) knownVerbs() []string {
+	from, _ := r.languagesFor(r.pair)
+	return append(r.translator.CachedVerbs(), lexicon.Verbs(from)...)
+}
+
+// settingValues returns the values offered for a setting in the set command
+func settingValues(name string) []string {
+	key, err := config.ResolveKey(name)
+	if err != nil {
+		return nil
+	}
+
+	switch key {
+	case "default_direction":
+		return directions()
+	case "default_tenses":
+		return config.GetAvailableTenses()
+	case "region":
+		return regionCodes()
+	default:
+		return []string{"on", "off"}
+	}
+}
+
+// regionCodes returns the codes of the supported Spanish regions
+func regionCodes() []string {
+	var codes []string
+	for _, region := range lang.Regions() {
+		codes = append(codes, region.Code)
+	}
+	return codes
+}
+
+// directions returns every supported "<from>2<to>" direction plus auto
+func directions() []string {
+	options := []string{"auto"}
+	for _, from := range lang.Codes() {
+		for _, to := range lang.Codes() {
+			if from != to {
+				options = append(options, lang.Direction(from, to))
+			}
+		}
+	}
+	return options
+}
+
+// isCommand reports whether word is a REPL command or alias
+func isCommand(word string) bool {
+	switch strings.ToLower(word) {
+	case "h", "t", "q", "cls":
+		return true
+	}
+	for _, c := range commands {
+		if strings.EqualFold(c, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPrefix returns the sorted, deduplicated options starting with prefix,
+// ignoring case and accents so "man" matches "mañana"
+func matchPrefix(options []string, prefix string) []string {
+	folded := lexicon.Fold(prefix)
+	seen := make(map[string]bool)
+	var matches []string
+	for _, option := range options {
+		if seen[option] || !strings.HasPrefix(lexicon.Fold(option), folded) {
+			continue
+		}
+		seen[option] = true
+		matches = append(matches, option)
+	}
+	sort.Strings(matches)
+	return matches
+}
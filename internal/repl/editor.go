@@ -0,0 +1,465 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// editAction tells the REPL how a call to ReadLine ended
+type editAction int
+
+const (
+	actionSubmit    editAction = iota // Enter was pressed
+	actionToggle                      // Ctrl+T was pressed
+	actionInterrupt                   // Ctrl+C was pressed
+	actionEOF                         // Ctrl+D on an empty line, or input closed
+)
+
+// lineEditor reads a single line from a raw-mode byte stream with cursor
+// movement, emacs-style editing keys and history navigation. The line is kept
+// as runes; cursor motion and deletion work on whole characters including any
+// combining marks, and redraws are measured in terminal columns.
+type lineEditor struct {
+	reader  *bufio.Reader
+	out     io.Writer
+	history *history
+	prompt  string
+	buf     []rune
+	pos     int    // cursor position in buf
+	histPos int    // history entry being shown, history.Len() for the new line
+	stash   []rune // the new line, kept while browsing history
+
+	// completer returns where the word being completed starts and its
+	// candidates; Tab does nothing when it is nil
+	completer func(line []rune, pos int) (int, []string)
+	menu      *completionMenu // candidates being cycled with Tab, if any
+}
+
+// completionMenu tracks the candidates cycled through by repeated Tab presses
+type completionMenu struct {
+	start      int // start of the word being completed
+	candidates []string
+	index      int // candidate currently in the line
+}
+
+// menuSize is the number of candidates shown in the menu at once
+const menuSize = 8
+
+// newLineEditor creates a line editor reading key presses from in and echoing
+// to out. The caller is responsible for putting a terminal into raw mode.
+func newLineEditor(in io.Reader, out io.Writer, h *history) *lineEditor {
+	return &lineEditor{
+		reader:  bufio.NewReader(in),
+		out:     out,
+		history: h,
+	}
+}
+
+// ReadLine reads one edited line. Submitted lines are added to the history.
+func (e *lineEditor) ReadLine(prompt string) (string, editAction, error) {
+	e.prompt = prompt
+	e.buf = e.buf[:0]
+	e.pos = 0
+	e.histPos = e.history.Len()
+	e.stash = nil
+	e.menu = nil
+	fmt.Fprint(e.out, prompt)
+
+	for {
+		b, err := e.reader.ReadByte()
+		if err != nil {
+			return "", actionEOF, err
+		}
+
+		// Any key but Tab accepts the current completion; escape sequences
+		// decide for themselves since Shift+Tab is one
+		if b != 9 && b != 27 {
+			e.closeMenu()
+		}
+
+		switch b {
+		case 3: // Ctrl+C
+			fmt.Fprint(e.out, "\r\n")
+			return "", actionInterrupt, nil
+		case 4: // Ctrl+D
+			if len(e.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", actionEOF, nil
+			}
+			e.deleteForward()
+		case 20: // Ctrl+T
+			fmt.Fprint(e.out, "\r\033[K") // Clear line
+			return "", actionToggle, nil
+		case 13, 10: // Enter (CR or LF)
+			fmt.Fprint(e.out, "\r\n")
+			line := string(e.buf)
+			e.history.Add(line)
+			return line, actionSubmit, nil
+		case 127, 8: // Backspace
+			e.backspace()
+		case 1: // Ctrl+A
+			e.moveTo(0)
+		case 5: // Ctrl+E
+			e.moveTo(len(e.buf))
+		case 2: // Ctrl+B
+			e.moveTo(e.prevChar(e.pos))
+		case 6: // Ctrl+F
+			e.moveTo(e.nextChar(e.pos))
+		case 11: // Ctrl+K
+			e.buf = e.buf[:e.pos]
+			e.refresh()
+		case 21: // Ctrl+U
+			e.buf = append(e.buf[:0], e.buf[e.pos:]...)
+			e.pos = 0
+			e.refresh()
+		case 23: // Ctrl+W
+			e.deleteWordBack()
+		case 16: // Ctrl+P
+			e.historyPrev()
+		case 14: // Ctrl+N
+			e.historyNext()
+		case 9: // Tab
+			e.completeNext(1)
+		case 27: // Escape sequence
+			e.handleEscape()
+		default:
+			if b >= 32 {
+				if r := e.readRune(b); r != utf8.RuneError && (runeWidth(r) > 0 || isMark(r)) {
+					e.insert(r)
+				}
+			}
+		}
+	}
+}
+
+// readRune completes a (possibly multibyte) character starting with b.
+// Invalid UTF-8 yields utf8.RuneError so the byte can be dropped.
+func (e *lineEditor) readRune(b byte) rune {
+	if b < utf8.RuneSelf {
+		return rune(b)
+	}
+
+	e.reader.UnreadByte()
+	r, _, err := e.reader.ReadRune()
+	if err != nil {
+		return utf8.RuneError
+	}
+	return r
+}
+
+// handleEscape interprets the key sent as an escape sequence
+func (e *lineEditor) handleEscape() {
+	b, err := e.reader.ReadByte()
+	if err != nil {
+		return
+	}
+
+	if b != '[' && b != 'O' {
+		e.closeMenu()
+	}
+
+	switch b {
+	case '[', 'O':
+		seq := e.readSequence()
+		if seq != "Z" {
+			e.closeMenu()
+		}
+
+		switch seq {
+		case "A": // Up
+			e.historyPrev()
+		case "B": // Down
+			e.historyNext()
+		case "C": // Right
+			e.moveTo(e.nextChar(e.pos))
+		case "D": // Left
+			e.moveTo(e.prevChar(e.pos))
+		case "H", "1~", "7~": // Home
+			e.moveTo(0)
+		case "F", "4~", "8~": // End
+			e.moveTo(len(e.buf))
+		case "3~": // Delete
+			e.deleteForward()
+		case "Z": // Shift+Tab
+			e.completeNext(-1)
+		case "1;5C", "1;3C": // Ctrl+Right, Alt+Right
+			e.moveTo(e.wordEnd())
+		case "1;5D", "1;3D": // Ctrl+Left, Alt+Left
+			e.moveTo(e.wordStart())
+		}
+	case 'b': // Alt+B
+		e.moveTo(e.wordStart())
+	case 'f': // Alt+F
+		e.moveTo(e.wordEnd())
+	case 'd': // Alt+D
+		end := e.wordEnd()
+		e.buf = append(e.buf[:e.pos], e.buf[end:]...)
+		e.refresh()
+	case 127, 8: // Alt+Backspace
+		e.deleteWordBack()
+	}
+}
+
+// readSequence reads the parameters and final byte of a CSI/SS3 sequence
+func (e *lineEditor) readSequence() string {
+	var seq []byte
+	for {
+		b, err := e.reader.ReadByte()
+		if err != nil {
+			return ""
+		}
+		seq = append(seq, b)
+		if b >= 0x40 && b <= 0x7e {
+			return string(seq)
+		}
+	}
+}
+
+// insert adds r at the cursor. Combining marks and characters following a
+// dead-key accent are composed with the previous character where possible,
+// so "e" + U+0301 and "´" + "e" both become "é".
+func (e *lineEditor) insert(r rune) {
+	if e.pos > 0 {
+		prev := e.buf[e.pos-1]
+		if isMark(r) {
+			if c, ok := compose(prev, r); ok {
+				e.buf[e.pos-1] = c
+				e.refresh()
+				return
+			}
+		} else if mark, ok := deadKeys[prev]; ok {
+			if c, ok := compose(r, mark); ok {
+				e.buf[e.pos-1] = c
+				e.refresh()
+				return
+			}
+		}
+	}
+
+	e.buf = append(e.buf, 0)
+	copy(e.buf[e.pos+1:], e.buf[e.pos:])
+	e.buf[e.pos] = r
+	e.pos++
+	e.refresh()
+}
+
+// backspace removes the character, with its combining marks, before the cursor
+func (e *lineEditor) backspace() {
+	if e.pos == 0 {
+		return
+	}
+	start := e.prevChar(e.pos)
+	e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+	e.pos = start
+	e.refresh()
+}
+
+// deleteForward removes the character, with its combining marks, under the cursor
+func (e *lineEditor) deleteForward() {
+	if e.pos >= len(e.buf) {
+		return
+	}
+	e.buf = append(e.buf[:e.pos], e.buf[e.nextChar(e.pos):]...)
+	e.refresh()
+}
+
+// prevChar returns the start of the character before index i, skipping
+// back over combining marks
+func (e *lineEditor) prevChar(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	i--
+	for i > 0 && isMark(e.buf[i]) {
+		i--
+	}
+	return i
+}
+
+// nextChar returns the index after the character at i and its combining marks
+func (e *lineEditor) nextChar(i int) int {
+	if i >= len(e.buf) {
+		return len(e.buf)
+	}
+	i++
+	for i < len(e.buf) && isMark(e.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// deleteWordBack removes the word before the cursor
+func (e *lineEditor) deleteWordBack() {
+	start := e.wordStart()
+	e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+	e.pos = start
+	e.refresh()
+}
+
+// wordStart returns the start of the word before the cursor
+func (e *lineEditor) wordStart() int {
+	i := e.pos
+	for i > 0 && unicode.IsSpace(e.buf[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(e.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after the cursor
+func (e *lineEditor) wordEnd() int {
+	i := e.pos
+	for i < len(e.buf) && unicode.IsSpace(e.buf[i]) {
+		i++
+	}
+	for i < len(e.buf) && !unicode.IsSpace(e.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// moveTo places the cursor at pos, clamped to the line
+func (e *lineEditor) moveTo(pos int) {
+	e.pos = max(0, min(pos, len(e.buf)))
+	e.refresh()
+}
+
+// historyPrev replaces the line with the previous history entry
+func (e *lineEditor) historyPrev() {
+	if e.histPos == 0 {
+		return
+	}
+	if e.histPos == e.history.Len() {
+		e.stash = append([]rune(nil), e.buf...)
+	}
+	e.histPos--
+	e.setLine([]rune(e.history.At(e.histPos)))
+}
+
+// historyNext replaces the line with the next history entry, or the new line
+func (e *lineEditor) historyNext() {
+	if e.histPos >= e.history.Len() {
+		return
+	}
+	e.histPos++
+	if e.histPos == e.history.Len() {
+		e.setLine(e.stash)
+		return
+	}
+	e.setLine([]rune(e.history.At(e.histPos)))
+}
+
+// setLine replaces the whole line and moves the cursor to its end
+func (e *lineEditor) setLine(line []rune) {
+	e.buf = append(e.buf[:0], line...)
+	e.pos = len(e.buf)
+	e.refresh()
+}
+
+// refresh redraws the prompt and line and moves the cursor back by the
+// display width of the text after it
+func (e *lineEditor) refresh() {
+	fmt.Fprintf(e.out, "\r%s%s\033[K", e.prompt, string(e.buf))
+	if back := runesWidth(e.buf[e.pos:]); back > 0 {
+		fmt.Fprintf(e.out, "\033[%dD", back)
+	}
+}
+
+// completeNext completes the word before the cursor. A single candidate is
+// inserted directly and several are first narrowed to their common prefix;
+// after that each Tab (or Shift+Tab, for step -1) cycles through a menu.
+func (e *lineEditor) completeNext(step int) {
+	if e.menu == nil {
+		if e.completer == nil {
+			return
+		}
+
+		start, candidates := e.completer(e.buf, e.pos)
+		switch len(candidates) {
+		case 0:
+			fmt.Fprint(e.out, "\a") // Bell
+			return
+		case 1:
+			e.replaceWord(start, candidates[0]+" ")
+			return
+		}
+
+		if prefix := []rune(commonPrefix(candidates)); len(prefix) > e.pos-start {
+			e.replaceWord(start, string(prefix))
+			return
+		}
+
+		e.menu = &completionMenu{start: start, candidates: candidates, index: -1}
+		if step < 0 {
+			e.menu.index = 0
+		}
+	}
+
+	n := len(e.menu.candidates)
+	e.menu.index = ((e.menu.index+step)%n + n) % n
+	e.replaceWord(e.menu.start, e.menu.candidates[e.menu.index])
+	e.drawMenu()
+}
+
+// replaceWord replaces the text from start to the cursor with word
+func (e *lineEditor) replaceWord(start int, word string) {
+	rest := append([]rune(nil), e.buf[e.pos:]...)
+	e.buf = append(append(e.buf[:start], []rune(word)...), rest...)
+	e.pos = start + len([]rune(word))
+	e.refresh()
+}
+
+// drawMenu shows the candidates on the line below, highlighting the current one
+func (e *lineEditor) drawMenu() {
+	candidates, index := e.menu.candidates, e.menu.index
+	first := max(0, min(index-menuSize/2, len(candidates)-menuSize))
+	last := min(first+menuSize, len(candidates))
+
+	var items []string
+	if first > 0 {
+		items = append(items, "…")
+	}
+	for i := first; i < last; i++ {
+		if i == index {
+			items = append(items, "\033[7m"+candidates[i]+"\033[0m")
+		} else {
+			items = append(items, candidates[i])
+		}
+	}
+	if last < len(candidates) {
+		items = append(items, "…")
+	}
+
+	fmt.Fprintf(e.out, "\r\n\033[K%s\033[1A", strings.Join(items, "  "))
+	e.refresh()
+}
+
+// closeMenu ends Tab cycling and clears the menu line
+func (e *lineEditor) closeMenu() {
+	if e.menu == nil {
+		return
+	}
+	e.menu = nil
+	fmt.Fprint(e.out, "\r\n\033[K\033[1A")
+	e.refresh()
+}
+
+// commonPrefix returns the longest prefix shared by all candidates
+func commonPrefix(candidates []string) string {
+	prefix := []rune(candidates[0])
+	for _, c := range candidates[1:] {
+		runes := []rune(c)
+		i := 0
+		for i < len(prefix) && i < len(runes) && prefix[i] == runes[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
@@ -0,0 +1,160 @@
+package repl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Key sequences as a terminal in raw mode sends them
+const (
+	keyLeft      = "\x1b[D"
+	keyRight     = "\x1b[C"
+	keyUp        = "\x1b[A"
+	keyDown      = "\x1b[B"
+	keyHome      = "\x1b[H"
+	keyEnd       = "\x1b[F"
+	keyHomeTilde = "\x1b[1~"
+	keyEndTilde  = "\x1b[4~"
+	keyBackspace = "\x7f"
+	keyCtrlW     = "\x17"
+	keyCtrlU     = "\x15"
+	keyCtrlK     = "\x0b"
+)
+
+// feed runs input through a line editor with the given history. Input that
+// doesn't end the line runs out, leaving the editor mid-edit to inspect.
+func feed(t *testing.T, input string, entries ...string) (*lineEditor, string, editAction, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	e := newLineEditor(bytes.NewReader([]byte(input)), &out, &history{entries: entries})
+	line, action, err := e.ReadLine("> ")
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadLine(%q): %v", input, err)
+	}
+	return e, line, action, &out
+}
+
+func TestEditorBuffer(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		buf   string
+		pos   int // Cursor position in runes
+	}{
+		{"ascii", "hola", "hola", 4},
+		{"multibyte", "año", "año", 3},
+		{"backspace removes ñ whole", "añ" + keyBackspace, "a", 1},
+		{"backspace after ñ", "niño" + keyBackspace + keyBackspace, "ni", 2},
+		{"combining acute composes", "cafe\u0301", "café", 4},
+		{"combining mark kept without precomposed form", "x\u0301", "x\u0301", 2},
+		{"backspace removes base and mark", "ax\u0301" + keyBackspace, "a", 1},
+		{"left skips base and mark", "x\u0301y" + keyLeft + keyLeft, "x\u0301y", 0},
+		{"dead key acute", "caf´e", "café", 4},
+		{"dead key diaeresis", "ping¨uino", "pingüino", 8},
+		{"dead key before consonant", "´x", "´x", 2},
+		{"wide runes", "日本語", "日本語", 3},
+		{"left over wide rune", "日本" + keyLeft, "日本", 1},
+		{"arrows", "abc" + keyLeft + keyLeft + "X" + keyRight + "Y", "aXbYc", 4},
+		{"home", "bc" + keyHome + "a", "abc", 1},
+		{"end", "ab" + keyHome + keyEnd + "c", "abc", 3},
+		{"home and end tilde", "b" + keyHomeTilde + "a" + keyEndTilde + "c", "abc", 3},
+		{"ctrl+a ctrl+e", "b\x01a\x05c", "abc", 3},
+		{"ctrl+w", "buenos días" + keyCtrlW, "buenos ", 7},
+		{"ctrl+w skips trailing space", "buenos días  " + keyCtrlW, "buenos ", 7},
+		{"ctrl+u", "buenos días" + keyLeft + keyLeft + keyCtrlU, "as", 0},
+		{"ctrl+k", "buenos días" + keyHome + keyRight + keyCtrlK, "b", 1},
+		{"delete", "abc" + keyHome + "\x1b[3~", "bc", 0},
+		{"ctrl+w then retype", "hola mundo" + keyCtrlW + "amigo", "hola amigo", 10},
+		{"invalid utf-8 dropped", "a\xffb", "ab", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _, _, _ := feed(t, tt.input)
+			if got := string(e.buf); got != tt.buf {
+				t.Errorf("buf = %q, want %q", got, tt.buf)
+			}
+			if e.pos != tt.pos {
+				t.Errorf("pos = %d, want %d", e.pos, tt.pos)
+			}
+		})
+	}
+}
+
+func TestEditorCursorWidth(t *testing.T) {
+	// Moving back over a wide rune takes two columns
+	_, _, _, out := feed(t, "日本"+keyLeft)
+	if !strings.HasSuffix(out.String(), "\x1b[2D") {
+		t.Errorf("output %q doesn't end by moving back 2 columns", out.String())
+	}
+
+	// A combining mark takes none
+	_, _, _, out = feed(t, "x\u0301y"+keyLeft)
+	if !strings.HasSuffix(out.String(), "\x1b[1D") {
+		t.Errorf("output %q doesn't end by moving back 1 column", out.String())
+	}
+}
+
+func TestEditorSubmit(t *testing.T) {
+	e, line, action, _ := feed(t, "m´as tarde\r")
+	if action != actionSubmit || line != "más tarde" {
+		t.Errorf("ReadLine = %q, %v; want \"más tarde\", submit", line, action)
+	}
+	if n := e.history.Len(); n != 1 || e.history.At(0) != "más tarde" {
+		t.Errorf("history = %v, want [más tarde]", e.history.entries)
+	}
+
+	if _, _, action, _ := feed(t, "\x14"); action != actionToggle {
+		t.Errorf("Ctrl+T action = %v, want toggle", action)
+	}
+	if _, _, action, _ := feed(t, "abc\x03"); action != actionInterrupt {
+		t.Errorf("Ctrl+C action = %v, want interrupt", action)
+	}
+	if _, _, action, _ := feed(t, "\x04"); action != actionEOF {
+		t.Errorf("Ctrl+D action = %v, want EOF", action)
+	}
+}
+
+func TestEditorHistory(t *testing.T) {
+	entries := []string{"hablar", "comer", "vivir"}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"up recalls last", keyUp + "\r", "vivir"},
+		{"up twice", keyUp + keyUp + "\r", "comer"},
+		{"up stops at oldest", keyUp + keyUp + keyUp + keyUp + "\r", "hablar"},
+		{"down returns to new line", "nue" + keyUp + keyUp + keyDown + keyDown + "vo\r", "nuevo"},
+		{"ctrl+p ctrl+n", "\x10\x10\x0e\r", "vivir"},
+		{"edit recalled line", keyUp + keyBackspace + keyBackspace + "endo\r", "vivendo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, line, action, _ := feed(t, tt.input, entries...)
+			if action != actionSubmit || line != tt.want {
+				t.Errorf("ReadLine = %q, %v; want %q", line, action, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditorCompletion(t *testing.T) {
+	var out bytes.Buffer
+	e := newLineEditor(strings.NewReader("ha\t\t\r"), &out, &history{})
+	e.completer = func(line []rune, pos int) (int, []string) {
+		return 0, matchPrefix([]string{"hablar", "hacer", "vivir"}, string(line[:pos]))
+	}
+
+	line, _, err := e.ReadLine("> ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != "hacer" {
+		t.Errorf("line = %q, want hacer after cycling to the second candidate", line)
+	}
+}
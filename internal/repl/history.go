@@ -0,0 +1,113 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tr/internal/config"
+)
+
+// maxHistory is the number of entries kept in memory and on disk
+const maxHistory = 1000
+
+// history stores previously entered lines and persists them across sessions.
+// A history with an empty path is kept in memory only.
+type history struct {
+	entries []string
+	path    string
+}
+
+// newHistory creates a history backed by the history file in config.Dir()
+func newHistory() *history {
+	h := &history{
+		path: filepath.Join(config.Dir(), "history"),
+	}
+	h.load()
+	return h
+}
+
+// load reads saved entries, silently ignoring a missing or unreadable file
+func (h *history) load() {
+	file, err := os.Open(h.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+
+	// Compact the file once it grows past the limit
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+		h.rewrite()
+	}
+}
+
+// Add records a line, skipping blanks and immediate repeats
+func (h *history) Add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[1:]
+	}
+
+	h.append(line)
+}
+
+// Len returns the number of entries
+func (h *history) Len() int {
+	return len(h.entries)
+}
+
+// At returns the entry at index i, oldest first
+func (h *history) At(i int) string {
+	return h.entries[i]
+}
+
+// Recent returns up to n of the newest entries, oldest first
+func (h *history) Recent(n int) []string {
+	if n > len(h.entries) {
+		n = len(h.entries)
+	}
+	return h.entries[len(h.entries)-n:]
+}
+
+// append adds a single line to the history file
+func (h *history) append(line string) {
+	if h.path == "" {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return // Silently fail, history is optional
+	}
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	file.WriteString(line + "\n")
+}
+
+// rewrite replaces the history file with the in-memory entries
+func (h *history) rewrite() {
+	if h.path == "" {
+		return
+	}
+
+	data := strings.Join(h.entries, "\n") + "\n"
+	os.WriteFile(h.path, []byte(data), 0600)
+}
@@ -3,46 +3,86 @@ package repl
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"os/signal"
 	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"tr/internal/config"
+	"tr/internal/lists"
+	"tr/internal/prompt"
 	"tr/internal/translator"
+	"tr/internal/vocab"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
 
 	"github.com/fatih/color"
 	"golang.org/x/term"
 )
 
+// defaultList is the word list save adds to when no name is given
+const defaultList = "saved"
+
 // REPL represents the interactive Read-Eval-Print Loop
 type REPL struct {
 	translator translator.Translator
-	direction  string // "es2en" or "en2es"
+	direction  string // "<from>2<to>" such as "es2en", or "auto"
+	pair       string // language pair auto mode detects between, e.g. "es2en"
+	detected   string // direction chosen for the last input in auto mode
+	session    *translator.Session
+	history    *history
+	vocab      *vocab.Store
+	lists      *lists.Store
+	editor     *lineEditor    // set in editor mode
+	scanner    *bufio.Scanner // set in line mode
 	running    bool
 	config     *config.Config
+	overrides  []config.Override // settings given as flags, kept across reset
 }
 
-// New creates a new REPL instance
-func New() *REPL {
-	cfg, err := config.LoadConfig()
+// New creates a new REPL instance. Overrides take precedence over the
+// configuration files and environment.
+func New(overrides ...config.Override) *REPL {
+	cfg, err := config.LoadConfig(overrides...)
 	if err != nil {
-		fmt.Printf("Warning: Failed to load config, using defaults: %v\n", err)
-		cfg = config.DefaultConfig()
+		fmt.Printf("Warning: ignoring invalid settings (run 'tr config doctor' for details):\n%v\n", err)
+	}
+
+	direction := cfg.DefaultDirection
+	pair := "es2en"
+	if direction != "auto" {
+		if _, _, err := lang.ParseDirection(direction); err != nil {
+			fmt.Printf("Warning: %v, using es2en\n", err)
+			direction = "es2en"
+		}
+		pair = direction
 	}
 
+	t := translator.New()
+	t.SetRegion(cfg.Region)
+
 	return &REPL{
-		translator: translator.New(),
-		direction:  cfg.DefaultDirection,
+		translator: t,
+		direction:  direction,
+		pair:       pair,
+		session:    translator.NewSession(),
+		history:    newHistory(),
+		vocab:      vocab.Open(),
+		lists:      lists.Open(),
 		running:    false,
 		config:     cfg,
+		overrides:  overrides,
 	}
 }
 
 // Start begins the interactive REPL session
 func (r *REPL) Start() error {
 	r.running = true
+	defer r.translator.Flush() // Keep lookups cached when the input ends
 
 	// Setup signal handling for graceful shutdown
 	r.setupSignalHandling()
@@ -50,14 +90,12 @@ func (r *REPL) Start() error {
 	// Display welcome message
 	r.displayWelcome()
 
-	// Setup terminal for raw input to capture key combinations
-	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
-	if err == nil {
-		defer term.Restore(int(os.Stdin.Fd()), oldState)
-		return r.runRawMode()
+	// Use the line editor when attached to a terminal to capture key combinations
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return r.runEditorMode()
 	}
 
-	// Fallback to line-by-line input if raw mode fails
+	// Fallback to line-by-line input for pipes and redirected input
 	return r.runLineMode()
 }
 
@@ -72,53 +110,29 @@ func (r *REPL) setupSignalHandling() {
 	}()
 }
 
-// runRawMode runs the REPL with raw terminal input for key combinations
-func (r *REPL) runRawMode() error {
-	var input strings.Builder
-	buf := make([]byte, 1)
+// runEditorMode runs the REPL with the raw-mode line editor, which supports
+// cursor movement, history and key combinations like Ctrl+T
+func (r *REPL) runEditorMode() error {
+	r.editor = newLineEditor(os.Stdin, os.Stdout, r.history)
+	r.editor.completer = r.complete
 
 	for r.running {
-		r.displayPrompt()
-		input.Reset()
+		r.displayStatus()
 
-	innerLoop:
-		for {
-			n, err := os.Stdin.Read(buf)
-			if err != nil || n == 0 {
-				continue
-			}
+		line, action, err := r.readLine(r.promptText())
+		if err != nil {
+			return nil // Input closed
+		}
 
-			char := buf[0]
-
-			switch char {
-			case 3: // Ctrl+C
-				r.shutdown()
-				return nil
-			case 20: // Ctrl+T
-				r.toggleDirection()
-				fmt.Print("\r\033[K") // Clear line
-				break innerLoop       // Break inner loop to show new prompt
-			case 13, 10: // Enter (CR or LF)
-				fmt.Println() // Move to next line
-				text := strings.TrimSpace(input.String())
-				if text != "" {
-					r.processInput(text)
-				}
-				break innerLoop // Break inner loop to show new prompt
-			case 127, 8: // Backspace or Delete
-				if input.Len() > 0 {
-					// Remove last character from input
-					inputStr := input.String()
-					input.Reset()
-					input.WriteString(inputStr[:len(inputStr)-1])
-					// Update display
-					fmt.Print("\b \b")
-				}
-			default:
-				if char >= 32 && char <= 126 || char >= 128 { // Printable characters
-					input.WriteByte(char)
-					fmt.Print(string(char))
-				}
+		switch action {
+		case actionInterrupt, actionEOF:
+			r.shutdown()
+			return nil
+		case actionToggle:
+			r.toggleDirection()
+		case actionSubmit:
+			if text := strings.TrimSpace(line); text != "" {
+				r.processInput(text)
 			}
 		}
 	}
@@ -126,18 +140,42 @@ func (r *REPL) runRawMode() error {
 	return nil
 }
 
+// readLine reads one line through the editor with the terminal in raw mode.
+// Raw mode is only held while reading so output printed between prompts
+// keeps normal newline handling.
+func (r *REPL) readLine(prompt string) (string, editAction, error) {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", actionEOF, err
+	}
+	defer term.Restore(fd, oldState)
+
+	return r.editor.ReadLine(prompt)
+}
+
+// choose offers options through prompt.Choose, reading the key press from
+// the editor's buffered reader. In line mode the options are only listed.
+func (r *REPL) choose(title string, options []string) (string, bool) {
+	var in io.Reader
+	if r.editor != nil {
+		in = r.editor.reader
+	}
+	return prompt.Choose(in, title, options)
+}
+
 // runLineMode runs the REPL with line-by-line input (fallback mode)
 func (r *REPL) runLineMode() error {
-	scanner := bufio.NewScanner(os.Stdin)
+	r.scanner = bufio.NewScanner(os.Stdin)
 
 	for r.running {
 		r.displayPrompt()
 
-		if !scanner.Scan() {
+		if !r.scanner.Scan() {
 			break
 		}
 
-		text := strings.TrimSpace(scanner.Text())
+		text := strings.TrimSpace(r.scanner.Text())
 		if text == "" {
 			continue
 		}
@@ -145,7 +183,26 @@ func (r *REPL) runLineMode() error {
 		r.processInput(text)
 	}
 
-	return scanner.Err()
+	return r.scanner.Err()
+}
+
+// ask reads an answer for study and drill sessions. Answers are kept out of
+// the input history. It returns false when the user cancels or input ends.
+func (r *REPL) ask(prompt string) (string, bool) {
+	if r.editor == nil {
+		fmt.Print(prompt)
+		if r.scanner == nil || !r.scanner.Scan() {
+			return "", false
+		}
+		return r.scanner.Text(), true
+	}
+
+	saved := r.editor.history
+	r.editor.history = &history{}
+	defer func() { r.editor.history = saved }()
+
+	line, action, err := r.readLine(prompt)
+	return line, err == nil && action == actionSubmit
 }
 
 // displayWelcome shows the initial welcome message
@@ -153,46 +210,85 @@ func (r *REPL) displayWelcome() {
 	titleColor := color.New(color.FgCyan, color.Bold)
 
 	fmt.Println(titleColor.Sprint("TR - English-Spanish Translator"))
-	fmt.Println("Type 'help' for commands, Ctrl+T to toggle direction, Ctrl+C to exit")
+	fmt.Println("Type 'help' for commands, Ctrl+T to switch direction, Ctrl+C to exit")
 	fmt.Println()
 }
 
 // displayPrompt shows the current prompt with direction indicator
 func (r *REPL) displayPrompt() {
+	r.displayStatus()
+	fmt.Print(r.promptText())
+}
+
+// displayStatus shows the direction indicator line above the prompt
+func (r *REPL) displayStatus() {
 	directionColor := color.New(color.FgGreen)
-	promptColor := color.New(color.FgBlue, color.Bold)
 
-	var directionText string
-	switch r.direction {
-	case "es2en":
-		directionText = "Spanish → English"
-	case "en2es":
-		directionText = "English → Spanish"
+	directionText := directionLabel(r.direction)
+	if r.direction == "auto" && r.detected != "" {
+		directionText = fmt.Sprintf("%s (last: %s)", directionText, directionLabel(r.detected))
 	}
 
-	fmt.Printf("%s\n%s ",
-		directionColor.Sprintf("Current direction: %s", directionText),
-		promptColor.Sprint(">"))
+	fmt.Println(directionColor.Sprintf("Current direction: %s", directionText))
 }
 
-// toggleDirection switches between es2en and en2es
+// promptText returns the input prompt
+func (r *REPL) promptText() string {
+	promptColor := color.New(color.FgBlue, color.Bold)
+	return promptColor.Sprint(">") + " "
+}
+
+// toggleDirection cycles through the language pair, its reverse and auto
 func (r *REPL) toggleDirection() {
-	if r.direction == "es2en" {
-		r.direction = "en2es"
-	} else {
-		r.direction = "es2en"
+	switch r.direction {
+	case "auto":
+		r.setDirection(r.pair)
+	case r.pair:
+		from, to := r.languagesFor(r.pair)
+		r.setDirection(lang.Direction(to, from))
+	default:
+		r.setDirection("auto")
+	}
+}
+
+// changeDirection handles the direction command, e.g. "direction fr2es"
+func (r *REPL) changeDirection(arg string) {
+	if arg == "auto" {
+		r.setDirection("auto")
+		return
+	}
+
+	from, to, err := lang.ParseDirection(arg)
+	if err != nil {
+		errorColor := color.New(color.FgRed)
+		fmt.Printf("%s\n\n", errorColor.Sprint(err))
+		return
 	}
 
+	r.pair = lang.Direction(from, to)
+	r.setDirection(r.pair)
+}
+
+// setDirection switches to the given direction and announces it
+func (r *REPL) setDirection(direction string) {
+	r.direction = direction
+	r.detected = ""
+
 	toggleColor := color.New(color.FgYellow, color.Bold)
-	var directionText string
-	switch r.direction {
-	case "es2en":
-		directionText = "Spanish → English"
-	case "en2es":
-		directionText = "English → Spanish"
+	fmt.Printf("\n%s\n\n", toggleColor.Sprintf("Switched to: %s", directionLabel(r.direction)))
+}
+
+// directionLabel returns a human readable name for a direction
+func directionLabel(direction string) string {
+	if direction == "auto" {
+		return "Auto-detect"
 	}
 
-	fmt.Printf("\n%s\n\n", toggleColor.Sprintf("Switched to: %s", directionText))
+	from, to, err := lang.ParseDirection(direction)
+	if err != nil {
+		return direction
+	}
+	return fmt.Sprintf("%s → %s", lang.Name(from), lang.Name(to))
 }
 
 // processInput handles user input and performs translation
@@ -201,8 +297,30 @@ func (r *REPL) processInput(input string) {
 
 	// Handle expand command for conjugations
 	if strings.HasPrefix(strings.ToLower(input), "expand ") {
-		verb := strings.TrimSpace(input[7:]) // Remove "expand "
-		r.expandConjugations(verb)
+		args := strings.Fields(input[7:]) // Remove "expand "
+		verb, tenses := "", args
+		if len(args) > 0 {
+			verb, tenses = args[0], args[1:]
+		}
+		r.expandConjugations(verb, tenses)
+		return
+	}
+
+	// Handle set command for changing settings
+	if strings.HasPrefix(strings.ToLower(input), "set ") {
+		r.setConfig(strings.TrimSpace(input[4:])) // Remove "set "
+		return
+	}
+
+	// Handle save command for bookmarking the last translation
+	if lower := strings.ToLower(input); lower == "save" || strings.HasPrefix(lower, "save ") {
+		r.save(strings.TrimSpace(input[4:])) // Remove "save"
+		return
+	}
+
+	// Handle direction command for switching language pairs
+	if strings.HasPrefix(strings.ToLower(input), "direction ") {
+		r.changeDirection(strings.TrimSpace(input[10:])) // Remove "direction "
 		return
 	}
 
@@ -216,52 +334,129 @@ func (r *REPL) processInput(input string) {
 	case "toggle", "t":
 		r.toggleDirection()
 		return
+	case "auto":
+		r.setDirection("auto")
+		return
 	case "clear", "cls":
 		r.clearScreen()
 		return
 	case "config":
 		r.showConfig()
 		return
+	case "reset":
+		r.resetConfig()
+		return
 	case "tenses":
 		r.showAvailableTenses()
 		return
+	case "languages":
+		r.showLanguages()
+		return
+	case "history":
+		r.showHistory()
+		return
+	case "study":
+		r.study()
+		return
 	}
 
-	// Perform translation
-	fromLang, toLang := r.getLanguages()
-	result, err := r.translator.Translate(input, fromLang, toLang)
+	r.translate(input)
+}
+
+// translate looks up input in the current direction and shows the result,
+// conjugations for verbs and spelling suggestions for unknown words
+func (r *REPL) translate(input string) {
+	// Perform translation, expanding accent shortcuts like n~ in Spanish input
+	fromLang, toLang := r.getLanguages(input)
+	input = lexicon.ExpandShortcuts(fromLang, input)
+
+	result, correction, err := translator.TranslateWithCorrection(r.translator, input, fromLang, toLang)
 	if err != nil {
 		errorColor := color.New(color.FgRed)
 		fmt.Printf("%s\n\n", errorColor.Sprintf("Translation error: %v", err))
 		return
 	}
+	if correction != nil {
+		input = correction.Corrected
+	}
 
 	// Display translation result
 	fmt.Println()
+	translator.DisplayCorrection(correction)
 	translator.DisplayTranslation(result, fromLang, toLang)
 
-	// Show conjugations if it's a Spanish verb
-	if fromLang == "es" && result.IsVerb {
-		translator.SetLastTranslatedVerb(input) // Store for expand command
-		conjugations, err := r.translator.GetConjugations(input)
+	// Remember real translations for study sessions and save
+	if !translator.IsLowConfidence(result) {
+		r.vocab.Record(result, fromLang, toLang)
+	}
+	r.session.SetLast(result, fromLang, toLang)
+
+	// Offer spellings from the lexicon when the word looks misspelled
+	if correction == nil && translator.IsLowConfidence(result) && !strings.Contains(input, " ") {
+		suggestions := lexicon.Suggest(fromLang, input, 5)
+		if choice, ok := r.choose("Did you mean:", suggestions); ok {
+			r.translate(choice)
+			return
+		}
+	}
+
+	// Show conjugations if it's a verb in a language with conjugation support
+	if lang.SupportsConjugation(fromLang) && result.IsVerb {
+		r.session.SetVerb(fromLang, input) // Store for expand command
+		conjugations, err := r.translator.GetConjugations(fromLang, input)
 		if err == nil && len(conjugations) > 0 {
-			translator.DisplayConjugationsExpandable(conjugations, r.config.DefaultTenses, r.config.ShowAllTenses)
+			translator.DisplayConjugationsExpandable(r.session, fromLang, conjugations, r.persons(fromLang), r.config.DefaultTenses, r.config.ShowAllTenses)
 		}
 	}
 
 	fmt.Println()
 }
 
-// getLanguages returns the from and to language codes based on current direction
-func (r *REPL) getLanguages() (from, to string) {
-	switch r.direction {
-	case "es2en":
-		return "es", "en"
-	case "en2es":
-		return "en", "es"
-	default:
+// persons returns the persons to show in conjugation tables for the configured region
+func (r *REPL) persons(language string) []string {
+	return lang.RegionPersons(language, r.config.Region)
+}
+
+// getLanguages returns the from and to language codes based on current direction.
+// In auto mode the direction is detected from the input itself.
+func (r *REPL) getLanguages(input string) (from, to string) {
+	if r.direction == "auto" {
+		from, to := r.languagesFor(r.pair)
+		if lang.Detect(input, from, to) == to {
+			from, to = to, from
+		}
+		r.detected = lang.Direction(from, to)
+		return from, to
+	}
+
+	return r.languagesFor(r.direction)
+}
+
+// conjugationLanguage picks the language to conjugate a verb given to
+// expand in: the pair's source if it has conjugations, then its target,
+// else Spanish. Unlike getLanguages it leaves the detected direction alone.
+func (r *REPL) conjugationLanguage() string {
+	direction := r.direction
+	if direction == "auto" {
+		direction = r.pair
+	}
+
+	from, to := r.languagesFor(direction)
+	for _, code := range []string{from, to} {
+		if lang.SupportsConjugation(code) {
+			return code
+		}
+	}
+	return "es"
+}
+
+// languagesFor returns the from and to language codes for a fixed direction
+func (r *REPL) languagesFor(direction string) (from, to string) {
+	from, to, err := lang.ParseDirection(direction)
+	if err != nil {
 		return "es", "en"
 	}
+	return from, to
 }
 
 // showHelp displays available commands
@@ -272,18 +467,35 @@ func (r *REPL) showHelp() {
 	fmt.Println()
 	fmt.Println(helpColor.Sprint("Available Commands:"))
 	fmt.Printf("  %s - Show this help message\n", commandColor.Sprint("help, h"))
-	fmt.Printf("  %s - Toggle translation direction\n", commandColor.Sprint("toggle, t"))
+	fmt.Printf("  %s - Cycle translation direction (pair, reverse, auto)\n", commandColor.Sprint("toggle, t"))
+	fmt.Printf("  %s - Detect the direction from each input\n", commandColor.Sprint("auto"))
+	fmt.Printf("  %s - Switch language pair, e.g. direction fr2es\n", commandColor.Sprint("direction [from2to]"))
+	fmt.Printf("  %s - List supported languages\n", commandColor.Sprint("languages"))
+	fmt.Printf("  %s - Show recent input history\n", commandColor.Sprint("history"))
+	fmt.Printf("  %s - Quiz words you looked up as flashcards\n", commandColor.Sprint("study"))
+	fmt.Printf("  %s - Save the last translation to a word list (default: %s)\n", commandColor.Sprint("save [list]"), defaultList)
 	fmt.Printf("  %s - Clear the screen\n", commandColor.Sprint("clear, cls"))
 	fmt.Printf("  %s - Exit the program\n", commandColor.Sprint("exit, quit, q"))
 	fmt.Printf("  %s - Show current configuration\n", commandColor.Sprint("config"))
+	fmt.Printf("  %s - Change a setting, e.g. set tenses present,future\n", commandColor.Sprint("set [setting] [value]"))
+	fmt.Printf("  %s - Restore the default configuration\n", commandColor.Sprint("reset"))
 	fmt.Printf("  %s - Show available tenses\n", commandColor.Sprint("tenses"))
-	fmt.Printf("  %s - Show all conjugations for a verb\n", commandColor.Sprint("expand [verb]"))
-	fmt.Printf("  %s - Toggle direction (keyboard shortcut)\n", commandColor.Sprint("Ctrl+T"))
-	fmt.Printf("  %s - Exit the program\n", commandColor.Sprint("Ctrl+C"))
+	fmt.Printf("  %s - Show all conjugations for a verb, or only some tenses\n", commandColor.Sprint("expand [verb] [tense...]"))
+	fmt.Printf("  %s - Cycle direction (keyboard shortcut)\n", commandColor.Sprint("Ctrl+T"))
+	fmt.Printf("  %s - Exit the program\n", commandColor.Sprint("Ctrl+C, Ctrl+D"))
+	fmt.Printf("  %s - Browse input history\n", commandColor.Sprint("Up/Down, Ctrl+P/N"))
+	fmt.Printf("  %s - Complete commands, tenses and looked-up words\n", commandColor.Sprint("Tab, Shift+Tab"))
+	fmt.Printf("  %s - Move the cursor, Home/End\n", commandColor.Sprint("Left/Right, Ctrl+A/E"))
+	fmt.Printf("  %s - Move by word\n", commandColor.Sprint("Ctrl+Left/Right, Alt+B/F"))
+	fmt.Printf("  %s - Delete previous word, delete to start/end of line\n", commandColor.Sprint("Ctrl+W, Ctrl+U/K"))
 	fmt.Println()
 	fmt.Println("Simply type any word or phrase to translate it.")
 	fmt.Println("For Spanish verbs, basic conjugations are shown automatically.")
+	fmt.Println("Other language pairs get plain translations.")
+	fmt.Println("Misspelled words get suggestions; press a number to look one up.")
+	fmt.Println("No Spanish keyboard? Type n~ for ñ, a' for á (any vowel) and u\" for ü.")
 	fmt.Println("Use 'expand' to see all available tenses and moods.")
+	fmt.Println("Set default_direction to \"auto\" in the config to start in auto-detect mode.")
 	fmt.Println()
 }
 
@@ -302,21 +514,32 @@ func (r *REPL) shutdown() {
 	r.running = false
 	farewellColor := color.New(color.FgGreen)
 	fmt.Printf("\n%s\n", farewellColor.Sprint("¡Adiós! Goodbye!"))
+	r.translator.Flush() // os.Exit skips deferred calls
 	os.Exit(0)
 }
 
-// expandConjugations shows all conjugations for a specific verb
-func (r *REPL) expandConjugations(verb string) {
+// expandConjugations shows all conjugations for a specific verb, or only the
+// given tenses if any are listed
+func (r *REPL) expandConjugations(verb string, tenses []string) {
+	var language string
 	if verb == "" {
-		verb = translator.GetLastTranslatedVerb()
+		language, verb = r.session.Verb()
 		if verb == "" {
 			errorColor := color.New(color.FgRed)
 			fmt.Printf("%s\n\n", errorColor.Sprint("No verb to expand. Please translate a verb first."))
 			return
 		}
+	} else {
+		language = r.conjugationLanguage()
 	}
 
-	conjugations, err := r.translator.GetConjugations(verb)
+	if !lang.SupportsConjugation(language) {
+		errorColor := color.New(color.FgRed)
+		fmt.Printf("%s\n\n", errorColor.Sprintf("Conjugations are not supported for %s", lang.Name(language)))
+		return
+	}
+
+	conjugations, err := r.translator.GetConjugations(language, verb)
 	if err != nil {
 		errorColor := color.New(color.FgRed)
 		fmt.Printf("%s\n\n", errorColor.Sprintf("Error getting conjugations: %v", err))
@@ -325,13 +548,30 @@ func (r *REPL) expandConjugations(verb string) {
 
 	if len(conjugations) == 0 {
 		infoColor := color.New(color.FgYellow)
-		fmt.Printf("%s\n\n", infoColor.Sprintf("No conjugations found for '%s'", verb))
+		fmt.Printf("%s\n", infoColor.Sprintf("No conjugations found for '%s'", verb))
+		if choice, ok := r.choose("Did you mean:", lexicon.SuggestVerbs(language, verb, 5)); ok {
+			r.expandConjugations(choice, tenses)
+			return
+		}
+		fmt.Println()
 		return
 	}
 
-	// Show all available tenses
+	for _, tense := range tenses {
+		if !slices.Contains(lang.Tenses(language), tense) {
+			errorColor := color.New(color.FgRed)
+			fmt.Printf("%s\n\n", errorColor.Sprintf("Unknown tense '%s'. Type 'tenses' to list them.", tense))
+			return
+		}
+	}
+
+	// Show the requested tenses, or all available ones
 	fmt.Println()
-	translator.DisplayConjugationsExpandable(conjugations, config.GetAvailableTenses(), true)
+	if len(tenses) > 0 {
+		translator.DisplayConjugationsExpandable(r.session, language, conjugations, r.persons(language), tenses, false)
+	} else {
+		translator.DisplayConjugationsExpandable(r.session, language, conjugations, r.persons(language), lang.Tenses(language), true)
+	}
 	fmt.Println()
 }
 
@@ -346,12 +586,64 @@ func (r *REPL) showConfig() {
 	fmt.Printf("  %s: %s\n", keyColor.Sprint("Default Direction"), valueColor.Sprint(r.config.DefaultDirection))
 	fmt.Printf("  %s: %s\n", keyColor.Sprint("Default Tenses"), valueColor.Sprint(strings.Join(r.config.DefaultTenses, ", ")))
 	fmt.Printf("  %s: %s\n", keyColor.Sprint("Show All Tenses"), valueColor.Sprint(r.config.ShowAllTenses))
+	fmt.Printf("  %s: %s\n", keyColor.Sprint("Region"), valueColor.Sprint(r.config.Region))
 	fmt.Println()
-	fmt.Println("Configuration file location: ~/.config/tr/config.json")
-	fmt.Println("Edit the file directly to change settings.")
+	fmt.Printf("Configuration file location: %s\n", config.Path())
+	fmt.Println("Use 'set [setting] [value]' to change a setting, or 'reset' to restore the defaults.")
 	fmt.Println()
 }
 
+// setConfig handles the set command, e.g. "set tenses present,future", and
+// saves the configuration
+func (r *REPL) setConfig(args string) {
+	errorColor := color.New(color.FgRed)
+
+	name, value, _ := strings.Cut(args, " ")
+	if strings.TrimSpace(value) == "" {
+		fmt.Printf("%s\n\n", errorColor.Sprint("Usage: set [setting] [value], e.g. set show_all on"))
+		return
+	}
+
+	// Save to the user's file only, so project and environment settings
+	// in effect for this session are not written into it
+	saved, _ := config.LoadUserConfig()
+	if err := saved.Set(name, value); err != nil {
+		fmt.Printf("%s\n\n", errorColor.Sprint(err))
+		return
+	}
+	if err := saved.Save(); err != nil {
+		fmt.Printf("%s\n\n", errorColor.Sprintf("Failed to save config: %v", err))
+		return
+	}
+	r.config.Set(name, value)
+	r.translator.SetRegion(r.config.Region)
+
+	key, _ := config.ResolveKey(name)
+	current, _ := r.config.Get(key)
+	savedColor := color.New(color.FgGreen)
+	fmt.Printf("%s\n", savedColor.Sprintf("Saved %s = %s", key, current))
+	if key == "default_direction" {
+		fmt.Println("The new default applies next session; use 'direction' or 'auto' to switch now.")
+	}
+	fmt.Println()
+}
+
+// resetConfig restores and saves the default configuration
+func (r *REPL) resetConfig() {
+	defaults := config.DefaultConfig()
+	if err := defaults.Save(); err != nil {
+		errorColor := color.New(color.FgRed)
+		fmt.Printf("%s\n\n", errorColor.Sprintf("Failed to save config: %v", err))
+		return
+	}
+	// Project, environment and flag settings still apply on top
+	r.config, _ = config.LoadConfig(r.overrides...)
+	r.translator.SetRegion(r.config.Region)
+
+	savedColor := color.New(color.FgGreen)
+	fmt.Printf("%s\n\n", savedColor.Sprint("Configuration reset to defaults."))
+}
+
 // showAvailableTenses displays all available tenses
 func (r *REPL) showAvailableTenses() {
 	tenseColor := color.New(color.FgGreen, color.Bold)
@@ -376,6 +668,92 @@ func (r *REPL) showAvailableTenses() {
 	fmt.Println()
 }
 
+// showLanguages displays all supported languages
+func (r *REPL) showLanguages() {
+	titleColor := color.New(color.FgCyan, color.Bold)
+	codeColor := color.New(color.FgYellow)
+
+	fmt.Println()
+	fmt.Println(titleColor.Sprint("Supported Languages:"))
+	for _, l := range lang.All() {
+		line := fmt.Sprintf("  %s %s", codeColor.Sprintf("%-3s", l.Code), l.Name)
+		if l.Conjugations {
+			line += " (conjugations)"
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Println("Use 'direction [from]2[to]' to switch pairs, e.g. 'direction pt2en'.")
+	fmt.Println()
+}
+
+// save bookmarks the last translation on a word list, "saved" by default
+func (r *REPL) save(name string) {
+	errorColor := color.New(color.FgRed)
+	last, from, to := r.session.Last()
+	if last == nil {
+		fmt.Printf("%s\n\n", errorColor.Sprint("Nothing to save yet. Translate a word first."))
+		return
+	}
+	if name == "" {
+		name = defaultList
+	}
+
+	added, err := r.lists.Add(name, last, from, to, time.Now())
+	if err == nil {
+		err = r.lists.Save()
+	}
+	if err != nil {
+		fmt.Printf("%s\n\n", errorColor.Sprintf("Error: %v", err))
+		return
+	}
+
+	saveColor := color.New(color.FgGreen)
+	if added {
+		fmt.Printf("%s\n\n", saveColor.Sprintf("Saved %s to %s", last.OriginalText, name))
+	} else {
+		fmt.Printf("%s\n\n", saveColor.Sprintf("%s is already on %s", last.OriginalText, name))
+	}
+}
+
+// study quizzes the vocabulary collected from lookups as flashcards
+func (r *REPL) study() {
+	infoColor := color.New(color.FgYellow)
+	if r.vocab.Len() == 0 {
+		fmt.Printf("%s\n\n", infoColor.Sprint("Nothing to study yet. Translate some words first."))
+		return
+	}
+
+	titleColor := color.New(color.FgCyan, color.Bold)
+	fmt.Println()
+	fmt.Println(titleColor.Sprint("Study Session"))
+	fmt.Println("Type the translation and press Enter. Ctrl+C or Ctrl+D ends the session.")
+	fmt.Println()
+
+	correct, asked := vocab.Study(r.vocab, r.ask, os.Stdout, 10, rand.New(rand.NewSource(time.Now().UnixNano())))
+	fmt.Printf("\n%s\n\n", titleColor.Sprintf("Score: %d/%d", correct, asked))
+}
+
+// showHistory displays the most recent input lines
+func (r *REPL) showHistory() {
+	titleColor := color.New(color.FgCyan, color.Bold)
+	indexColor := color.New(color.FgWhite)
+
+	recent := r.history.Recent(20)
+	fmt.Println()
+	fmt.Println(titleColor.Sprint("Recent History:"))
+	for i, line := range recent {
+		fmt.Printf("  %s %s\n", indexColor.Sprintf("%4d", r.history.Len()-len(recent)+i+1), line)
+	}
+	fmt.Println()
+	if r.history.path != "" {
+		fmt.Printf("History is saved to %s.\n", r.history.path)
+	} else {
+		fmt.Println("History is kept for this session only.")
+	}
+	fmt.Println()
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	return slices.Contains(slice, item)
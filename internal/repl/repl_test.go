@@ -0,0 +1,86 @@
+package repl
+
+import (
+	"testing"
+
+	"tr/internal/config"
+	"tr/internal/translator"
+	"tr/pkg/conjugation"
+)
+
+// stubTranslator conjugates any verb and records the language asked for
+type stubTranslator struct {
+	languages []string
+}
+
+func (s *stubTranslator) Translate(text, from, to string) (*translator.TranslationResult, error) {
+	return &translator.TranslationResult{OriginalText: text, Translation: text, Confidence: 1}, nil
+}
+
+func (s *stubTranslator) GetConjugations(language, verb string) (conjugation.Table, error) {
+	s.languages = append(s.languages, language)
+	return conjugation.Table{"present": {"yo": "hablo"}}, nil
+}
+
+func (s *stubTranslator) CachedVerbs() []string { return nil }
+
+func (s *stubTranslator) CachedTranslation(text, from, to string) (*translator.TranslationResult, bool) {
+	return nil, false
+}
+
+func (s *stubTranslator) SetRegion(region string) {}
+
+func (s *stubTranslator) Flush() {}
+
+// testREPL returns a REPL in direction (and auto mode's pair) backed by a stub
+func testREPL(direction, pair string) (*REPL, *stubTranslator) {
+	t := &stubTranslator{}
+	return &REPL{
+		translator: t,
+		direction:  direction,
+		pair:       pair,
+		session:    translator.NewSession(),
+		config:     config.DefaultConfig(),
+	}, t
+}
+
+func TestExpandLanguage(t *testing.T) {
+	tests := []struct {
+		direction string
+		pair      string
+		want      string
+	}{
+		{"es2en", "es2en", "es"},
+		{"en2es", "en2es", "es"}, // The target conjugates when the source can't
+		{"fr2es", "fr2es", "fr"},
+		{"en2de", "en2de", "es"}, // Neither side conjugates
+		{"auto", "en2it", "it"},
+	}
+
+	for _, tt := range tests {
+		r, stub := testREPL(tt.direction, tt.pair)
+		r.processInput("expand hablar present")
+
+		if len(stub.languages) != 1 || stub.languages[0] != tt.want {
+			t.Errorf("%s (pair %s): conjugated in %v, want [%s]", tt.direction, tt.pair, stub.languages, tt.want)
+		}
+		if r.detected != "" {
+			t.Errorf("%s: expand set the detected direction to %q", tt.direction, r.detected)
+		}
+	}
+}
+
+func TestExpandLastVerb(t *testing.T) {
+	r, stub := testREPL("en2es", "en2es")
+	r.expandConjugations("", nil)
+	if len(stub.languages) != 0 {
+		t.Errorf("expand before any verb conjugated in %v", stub.languages)
+	}
+
+	// The last verb keeps the language it was translated from
+	r.session.SetVerb("fr", "parler")
+	r.expandConjugations("", nil)
+	if len(stub.languages) != 1 || stub.languages[0] != "fr" {
+		t.Errorf("expand of the last verb conjugated in %v, want [fr]", stub.languages)
+	}
+}
@@ -0,0 +1,109 @@
+package repl
+
+import "unicode"
+
+// runeWidth returns the number of terminal columns r occupies: 0 for
+// combining marks and control characters, 2 for wide East Asian characters
+// and emoji, 1 otherwise
+func runeWidth(r rune) int {
+	switch {
+	case r == 0, unicode.IsControl(r):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// runesWidth returns the total column width of runes
+func runesWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// wideRanges lists the code point ranges rendered two columns wide
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, CJK symbols
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Emoji and pictographs
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x20000, 0x3FFFD}, // CJK extensions B and later
+}
+
+// isWide reports whether r is rendered two columns wide
+func isWide(r rune) bool {
+	for _, rng := range wideRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// isMark reports whether r combines with the preceding character
+func isMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// Combining marks used by Spanish and the other Romance languages
+const (
+	markGrave      = '\u0300'
+	markAcute      = '\u0301'
+	markCircumflex = '\u0302'
+	markTilde      = '\u0303'
+	markDiaeresis  = '\u0308'
+	markCedilla    = '\u0327'
+)
+
+// deadKeys maps the spacing accents some terminals send for dead keys that
+// were not composed by the system to the matching combining mark
+var deadKeys = map[rune]rune{
+	'\u00b4': markAcute,      // ´ acute accent
+	'\u00a8': markDiaeresis,  // ¨ diaeresis
+	'\u02dc': markTilde,      // ˜ small tilde
+	'\u02c6': markCircumflex, // ˆ modifier circumflex
+	'\u02cb': markGrave,      // ˋ modifier grave
+	'\u00b8': markCedilla,    // ¸ cedilla
+}
+
+// precomposed maps a base letter and combining mark to the single code point
+var precomposed = map[[2]rune]rune{}
+
+// init fills precomposed from compact per-mark tables
+func init() {
+	tables := map[rune][2]string{
+		markGrave:      {"aeiouAEIOU", "àèìòùÀÈÌÒÙ"},
+		markAcute:      {"aeiouyAEIOUY", "áéíóúýÁÉÍÓÚÝ"},
+		markCircumflex: {"aeiouAEIOU", "âêîôûÂÊÎÔÛ"},
+		markTilde:      {"anoANO", "ãñõÃÑÕ"},
+		markDiaeresis:  {"aeiouyAEIOU", "äëïöüÿÄËÏÖÜ"},
+		markCedilla:    {"cC", "çÇ"},
+	}
+	for mark, pair := range tables {
+		bases, composed := []rune(pair[0]), []rune(pair[1])
+		for i, base := range bases {
+			precomposed[[2]rune{base, mark}] = composed[i]
+		}
+	}
+}
+
+// compose joins a base letter and combining mark into one code point
+func compose(base, mark rune) (rune, bool) {
+	r, ok := precomposed[[2]rune{base, mark}]
+	return r, ok
+}
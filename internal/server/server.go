@@ -0,0 +1,304 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"tr/internal/translator"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+)
+
+// Server serves the translator as a JSON API:
+//
+//	GET /translate?text=hola&from=es&to=en (or direction=es2en)
+//	GET /conjugate?verb=hablar&lang=es&tenses=present,future
+//	GET /detect?text=hello&candidates=es,en
+//	GET /health
+//
+// Parameters can also be sent as a JSON object or form in a POST body.
+type Server struct {
+	translator translator.Translator
+	direction  string // Default "<from>2<to>", or "auto"
+	version    string
+	logger     *log.Logger
+	mux        *http.ServeMux
+}
+
+// New creates a server backed by t. Requests without a language pair use
+// direction; each request is logged to logger unless it is nil.
+func New(t translator.Translator, direction, version string, logger *log.Logger) *Server {
+	s := &Server{
+		translator: t,
+		direction:  direction,
+		version:    version,
+		logger:     logger,
+		mux:        http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/translate", s.handleTranslate)
+	s.mux.HandleFunc("/conjugate", s.handleConjugate)
+	s.mux.HandleFunc("/detect", s.handleDetect)
+	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no endpoint %s", r.URL.Path))
+	})
+	return s
+}
+
+// ServeHTTP routes a request and logs it
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r)
+
+	if s.logger != nil {
+		s.logger.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Microsecond))
+	}
+}
+
+// TranslateResponse is the body returned by /translate
+type TranslateResponse struct {
+	Text        string  `json:"text"`
+	Translation string  `json:"translation"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	IsVerb      bool    `json:"is_verb"`
+	Confidence  float64 `json:"confidence"`
+	Corrected   string  `json:"corrected,omitempty"` // Accented spelling that was translated instead
+}
+
+// ConjugateResponse is the body returned by /conjugate
+type ConjugateResponse struct {
+	Verb         string                       `json:"verb"`
+	Lang         string                       `json:"lang"`
+	Conjugations map[string]map[string]string `json:"conjugations"` // Tense -> person -> form
+}
+
+// DetectResponse is the body returned by /detect
+type DetectResponse struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleTranslate(w http.ResponseWriter, r *http.Request) {
+	params, ok := s.params(w, r)
+	if !ok {
+		return
+	}
+
+	text := strings.TrimSpace(params.Get("text"))
+	if text == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing parameter: text"))
+		return
+	}
+
+	from, to, err := s.pair(params, text)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	text = lexicon.ExpandShortcuts(from, text)
+
+	result, correction, err := translator.TranslateWithCorrection(s.translator, text, from, to)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	resp := TranslateResponse{
+		Text:        text,
+		Translation: result.Translation,
+		From:        from,
+		To:          to,
+		IsVerb:      result.IsVerb,
+		Confidence:  result.Confidence,
+	}
+	if correction != nil {
+		resp.Corrected = correction.Corrected
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleConjugate(w http.ResponseWriter, r *http.Request) {
+	params, ok := s.params(w, r)
+	if !ok {
+		return
+	}
+
+	verb := strings.ToLower(strings.TrimSpace(params.Get("verb")))
+	if verb == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing parameter: verb"))
+		return
+	}
+	language := params.Get("lang")
+	if language == "" {
+		language = "es"
+	}
+	if !lang.SupportsConjugation(language) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("conjugations are not supported for %q", language))
+		return
+	}
+
+	var tenses []string
+	if list := params.Get("tenses"); list != "" {
+		for _, tense := range strings.Split(list, ",") {
+			tense = strings.TrimSpace(tense)
+			if !slices.Contains(lang.Tenses(language), tense) {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("unknown tense %q (valid: %s)", tense, strings.Join(lang.Tenses(language), ", ")))
+				return
+			}
+			tenses = append(tenses, tense)
+		}
+	}
+
+	conjugations, err := s.translator.GetConjugations(language, verb)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	if len(conjugations) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no conjugations found for %q", verb))
+		return
+	}
+
+	if tenses != nil {
+		selected := make(map[string]map[string]string)
+		for _, tense := range tenses {
+			if forms, ok := conjugations[tense]; ok {
+				selected[tense] = forms
+			}
+		}
+		conjugations = selected
+	}
+	writeJSON(w, http.StatusOK, ConjugateResponse{Verb: verb, Lang: language, Conjugations: conjugations})
+}
+
+func (s *Server) handleDetect(w http.ResponseWriter, r *http.Request) {
+	params, ok := s.params(w, r)
+	if !ok {
+		return
+	}
+
+	text := params.Get("text")
+	if strings.TrimSpace(text) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing parameter: text"))
+		return
+	}
+
+	candidates := lang.Codes()
+	if list := params.Get("candidates"); list != "" {
+		candidates = nil
+		for _, code := range strings.Split(list, ",") {
+			code = strings.TrimSpace(code)
+			if _, ok := lang.Lookup(code); !ok {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("unknown language %q (valid: %s)", code, strings.Join(lang.Codes(), ", ")))
+				return
+			}
+			candidates = append(candidates, code)
+		}
+	}
+
+	code := lang.Detect(text, candidates...)
+	writeJSON(w, http.StatusOK, DetectResponse{Language: code, Name: lang.Name(code)})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": s.version})
+}
+
+// params returns the request parameters from the query string, a form or a
+// JSON object body. It writes an error response and returns false if the
+// method isn't allowed or the body can't be read.
+func (s *Server) params(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	switch r.Method {
+	case http.MethodGet:
+		return r.URL.Query(), true
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return nil, false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid form: %w", err))
+			return nil, false
+		}
+		return r.Form, true
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+		return nil, false
+	}
+	params := r.URL.Query()
+	for key, value := range body {
+		params.Set(key, value)
+	}
+	return params, true
+}
+
+// pair returns the language pair of a translation from the from/to or
+// direction parameters, falling back to the server's default direction.
+// Auto-detection picks Spanish or English, as on the command line.
+func (s *Server) pair(params url.Values, text string) (string, string, error) {
+	from, to, direction := params.Get("from"), params.Get("to"), params.Get("direction")
+	switch {
+	case from != "" && to != "":
+		return lang.ParsePair(from, to)
+	case from != "":
+		return lang.ParsePair(from, lang.Counterpart(from))
+	case to != "":
+		return lang.ParsePair(lang.Counterpart(to), to)
+	case direction == "":
+		direction = s.direction
+	}
+
+	if direction != "auto" && direction != "" {
+		return lang.ParseDirection(direction)
+	}
+	if lang.Detect(text, "es", "en") == "en" {
+		return "en", "es", nil
+	}
+	return "es", "en", nil
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// statusRecorder remembers the status code written, for logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
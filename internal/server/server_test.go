@@ -0,0 +1,222 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tr/internal/translator"
+	"tr/pkg/conjugation"
+)
+
+// stubTranslator translates from a fixed dictionary and conjugates hablar
+type stubTranslator struct {
+	words map[string]string
+}
+
+func (s *stubTranslator) Translate(text, from, to string) (*translator.TranslationResult, error) {
+	translation, ok := s.words[strings.ToLower(text)]
+	if !ok {
+		return nil, errors.New("service unavailable")
+	}
+	return &translator.TranslationResult{
+		OriginalText: text,
+		Translation:  translation,
+		IsVerb:       strings.HasSuffix(text, "ar"),
+		Confidence:   1,
+	}, nil
+}
+
+func (s *stubTranslator) GetConjugations(language, verb string) (conjugation.Table, error) {
+	if verb != "hablar" {
+		return nil, nil
+	}
+	return conjugation.Table{
+		"present": {"yo": "hablo", "tú": "hablas"},
+		"future":  {"yo": "hablaré", "tú": "hablarás"},
+	}, nil
+}
+
+func (s *stubTranslator) CachedVerbs() []string { return nil }
+
+func (s *stubTranslator) CachedTranslation(text, from, to string) (*translator.TranslationResult, bool) {
+	return nil, false
+}
+
+func (s *stubTranslator) SetRegion(region string) {}
+
+func (s *stubTranslator) Flush() {}
+
+func newTestServer() *Server {
+	t := &stubTranslator{words: map[string]string{
+		"hola":         "hello",
+		"hablar":       "to speak",
+		"good morning": "buenos días",
+	}}
+	return New(t, "es2en", "1.2.3", nil)
+}
+
+// do sends a request through the server and decodes the JSON response
+func do(t *testing.T, s *Server, req *http.Request) (int, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("%s %s: Content-Type = %q, want JSON", req.Method, req.URL, ct)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s %s: invalid JSON %q: %v", req.Method, req.URL, rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+func TestTranslate(t *testing.T) {
+	s := newTestServer()
+	tests := []struct {
+		name     string
+		req      *http.Request
+		text     string
+		want     string
+		from, to string
+	}{
+		{"query", httptest.NewRequest("GET", "/translate?text=hola&from=es&to=en", nil), "hola", "hello", "es", "en"},
+		{"default direction", httptest.NewRequest("GET", "/translate?text=hola", nil), "hola", "hello", "es", "en"},
+		{"direction", httptest.NewRequest("GET", "/translate?text=good+morning&direction=en2es", nil), "good morning", "buenos días", "en", "es"},
+		{"form", formRequest("/translate", "text=hola&direction=es2en"), "hola", "hello", "es", "en"},
+		{"json", jsonRequest("/translate", `{"text":"good morning","direction":"en2es"}`), "good morning", "buenos días", "en", "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := do(t, s, tt.req)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, want 200 (%v)", status, body)
+			}
+			if body["text"] != tt.text || body["translation"] != tt.want || body["from"] != tt.from || body["to"] != tt.to {
+				t.Errorf("body = %v, want %s -> %s (%s2%s)", body, tt.text, tt.want, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestConjugate(t *testing.T) {
+	s := newTestServer()
+	status, body := do(t, s, httptest.NewRequest("GET", "/conjugate?verb=Hablar&tenses=present", nil))
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (%v)", status, body)
+	}
+	if body["verb"] != "hablar" || body["lang"] != "es" {
+		t.Errorf("verb, lang = %v, %v; want hablar, es", body["verb"], body["lang"])
+	}
+
+	conjugations := body["conjugations"].(map[string]any)
+	if len(conjugations) != 1 {
+		t.Errorf("tenses = %v, want only present", conjugations)
+	}
+	if yo := conjugations["present"].(map[string]any)["yo"]; yo != "hablo" {
+		t.Errorf("present yo = %v, want hablo", yo)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	s := newTestServer()
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"text=buenos+d%C3%ADas&candidates=es,en", "es"},
+		{"text=good+morning+my+friend&candidates=es,en", "en"},
+	}
+
+	for _, tt := range tests {
+		status, body := do(t, s, httptest.NewRequest("GET", "/detect?"+tt.query, nil))
+		if status != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200 (%v)", tt.query, status, body)
+		}
+		if body["language"] != tt.want {
+			t.Errorf("%s: language = %v, want %s", tt.query, body["language"], tt.want)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	srv := httptest.NewServer(newTestServer())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || body["status"] != "ok" || body["version"] != "1.2.3" {
+		t.Errorf("GET /health = %d %v, want 200 ok 1.2.3", resp.StatusCode, body)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newTestServer()
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+		error  string // Substring of the error message
+	}{
+		{"missing text", httptest.NewRequest("GET", "/translate", nil), http.StatusBadRequest, "missing parameter: text"},
+		{"bad direction", httptest.NewRequest("GET", "/translate?text=hola&direction=xx2yy", nil), http.StatusBadRequest, "xx"},
+		{"bad json", jsonRequest("/translate", `{"text":`), http.StatusBadRequest, "invalid JSON body"},
+		{"service failure", httptest.NewRequest("GET", "/translate?text=adiós&from=es&to=en", nil), http.StatusBadGateway, "service unavailable"},
+		{"missing verb", httptest.NewRequest("GET", "/conjugate", nil), http.StatusBadRequest, "missing parameter: verb"},
+		{"unknown tense", httptest.NewRequest("GET", "/conjugate?verb=hablar&tenses=someday", nil), http.StatusBadRequest, `unknown tense "someday"`},
+		{"unsupported language", httptest.NewRequest("GET", "/conjugate?verb=walk&lang=en", nil), http.StatusBadRequest, "not supported"},
+		{"unknown verb", httptest.NewRequest("GET", "/conjugate?verb=xyz", nil), http.StatusNotFound, `no conjugations found for "xyz"`},
+		{"unknown candidate", httptest.NewRequest("GET", "/detect?text=hola&candidates=es,xx", nil), http.StatusBadRequest, `unknown language "xx"`},
+		{"unknown endpoint", httptest.NewRequest("GET", "/nope", nil), http.StatusNotFound, "no endpoint /nope"},
+		{"method", httptest.NewRequest("DELETE", "/translate?text=hola", nil), http.StatusMethodNotAllowed, "method DELETE not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := do(t, s, tt.req)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			message, ok := body["error"].(string)
+			if !ok || len(body) != 1 {
+				t.Fatalf("body = %v, want {\"error\": ...}", body)
+			}
+			if !strings.Contains(message, tt.error) {
+				t.Errorf("error = %q, want it to contain %q", message, tt.error)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowedSetsAllow(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest("PUT", "/detect", nil))
+	if allow := rec.Header().Get("Allow"); allow != "GET, POST" {
+		t.Errorf("Allow = %q, want \"GET, POST\"", allow)
+	}
+}
+
+func formRequest(path, body string) *http.Request {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func jsonRequest(path, body string) *http.Request {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
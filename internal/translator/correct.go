@@ -0,0 +1,37 @@
+package translator
+
+import (
+	"fmt"
+
+	"tr/pkg/tr"
+
+	"github.com/fatih/color"
+)
+
+// Correction records an accent fix applied to the input before translating
+type Correction = tr.Correction
+
+// IsLowConfidence reports whether a translation looks like a failed lookup
+func IsLowConfidence(result *TranslationResult) bool {
+	return tr.IsLowConfidence(result)
+}
+
+// TranslateWithCorrection translates text, retrying with accented spellings
+// when the lookup fails, see tr.TranslateWithCorrection
+func TranslateWithCorrection(t Translator, text, from, to string) (*TranslationResult, *Correction, error) {
+	return tr.TranslateWithCorrection(t, text, from, to)
+}
+
+// DisplayCorrection tells the user which spelling was translated instead of their input
+func DisplayCorrection(c *Correction) {
+	if c == nil {
+		return
+	}
+
+	infoColor := color.New(color.FgYellow)
+	wordColor := color.New(color.FgYellow, color.Bold)
+	fmt.Printf("%s %s%s\n",
+		infoColor.Sprint("Did you mean"),
+		wordColor.Sprint(c.Corrected),
+		infoColor.Sprintf("? Showing results for it instead of %q.", c.Original))
+}
@@ -0,0 +1,59 @@
+package translator
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Session holds the state of one interactive session: the verb whose
+// conjugations were shown last, which expand works on, and the last
+// translation, which save bookmarks. Each REPL has its own session, so
+// sessions sharing a Translator don't see each other's state.
+type Session struct {
+	// ExpandHint tells the user how to see the tenses of a verb hidden by
+	// DisplayConjugationsExpandable. It names the REPL's expand command by
+	// default.
+	ExpandHint func(language, verb string) string
+
+	mu       sync.Mutex
+	verb     string
+	verbLang string
+	last     *TranslationResult
+	lastFrom string
+	lastTo   string
+}
+
+// NewSession creates an empty session
+func NewSession() *Session {
+	return &Session{ExpandHint: func(language, verb string) string {
+		return fmt.Sprintf("Type 'expand %s' to see all conjugations.", verb)
+	}}
+}
+
+// SetVerb remembers the verb whose conjugations are shown and its language
+func (s *Session) SetVerb(language, verb string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verb, s.verbLang = verb, language
+}
+
+// Verb returns the last verb shown and its language, empty if there is none
+func (s *Session) Verb() (language, verb string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.verbLang, s.verb
+}
+
+// SetLast remembers a translation and its direction
+func (s *Session) SetLast(result *TranslationResult, from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last, s.lastFrom, s.lastTo = result, from, to
+}
+
+// Last returns the last translation and its direction, nil if there is none
+func (s *Session) Last() (result *TranslationResult, from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last, s.lastFrom, s.lastTo
+}
@@ -0,0 +1,348 @@
+// Package tui is the full-screen interface of tr tui: an input bar, a
+// scrolling results pane, a conjugation panel with tense tabs and a history
+// of this session's lookups.
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tr/internal/config"
+	"tr/internal/translator"
+	"tr/internal/vocab"
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TUI is a full-screen translator session
+type TUI struct {
+	translator translator.Translator
+	config     *config.Config
+	vocab      *vocab.Store
+	direction  string // "<from>2<to>" such as "es2en", or "auto"
+	pair       string // language pair auto mode detects between
+
+	app     *tview.Application
+	input   *tview.InputField
+	results *tview.TextView
+	tabs    *tview.TextView
+	table   *tview.Table
+	history *tview.List
+	focus   []tview.Primitive // Panes in the order Tab cycles through
+
+	// Conjugation panel state
+	language     string
+	verb         string
+	conjugations conjugation.Table
+	tenses       []string
+	tense        int // Index of the selected tab in tenses
+}
+
+// New creates a TUI translating with t in the configured default direction
+func New(t translator.Translator, cfg *config.Config) *TUI {
+	direction := cfg.DefaultDirection
+	pair := "es2en"
+	if direction != "auto" {
+		if _, _, err := lang.ParseDirection(direction); err != nil {
+			direction = "es2en"
+		}
+		pair = direction
+	}
+
+	ui := &TUI{
+		translator: t,
+		config:     cfg,
+		vocab:      vocab.Open(),
+		direction:  direction,
+		pair:       pair,
+		app:        tview.NewApplication(),
+	}
+	ui.layout()
+	return ui
+}
+
+// Run shows the interface until the user quits
+func (ui *TUI) Run() error {
+	return ui.app.Run()
+}
+
+// layout builds the panes: results and input on the left, conjugations and
+// history on the right
+func (ui *TUI) layout() {
+	ui.results = tview.NewTextView()
+	ui.results.SetDynamicColors(true)
+	ui.results.SetScrollable(true)
+	ui.results.SetWrap(true)
+	ui.results.SetBorder(true)
+	ui.results.SetTitle(" Results ")
+	fmt.Fprintln(ui.results, "[::d]Type a word and press Enter. Tab switches panes, ←/→ switch tenses, Ctrl+T toggles the direction and Esc quits.[::-]")
+
+	ui.input = tview.NewInputField()
+	ui.input.SetFieldBackgroundColor(tcell.ColorDefault)
+	ui.input.SetBorder(true)
+	ui.input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			text := strings.TrimSpace(ui.input.GetText())
+			ui.input.SetText("")
+			ui.submit(text)
+		}
+	})
+	ui.updateLabel()
+
+	ui.tabs = tview.NewTextView()
+	ui.tabs.SetDynamicColors(true)
+	ui.tabs.SetRegions(true)
+	ui.tabs.SetWrap(false)
+
+	ui.table = tview.NewTable()
+	ui.table.SetSelectable(false, false)
+	ui.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyLeft:
+			ui.selectTense(ui.tense - 1)
+			return nil
+		case tcell.KeyRight:
+			ui.selectTense(ui.tense + 1)
+			return nil
+		}
+		return event
+	})
+
+	conjugations := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(ui.tabs, 1, 0, false).
+		AddItem(ui.table, 0, 1, false)
+	conjugations.SetBorder(true)
+	conjugations.SetTitle(" Conjugations ")
+
+	ui.history = tview.NewList()
+	ui.history.ShowSecondaryText(false)
+	ui.history.SetBorder(true)
+	ui.history.SetTitle(" History ")
+	ui.history.SetSelectedFunc(func(_ int, text, _ string, _ rune) {
+		ui.app.SetFocus(ui.input)
+		ui.addHistory(text)
+		ui.translate(text)
+	})
+
+	left := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(ui.results, 0, 1, false).
+		AddItem(ui.input, 3, 0, true)
+	right := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(conjugations, 0, 2, false).
+		AddItem(ui.history, 0, 1, false)
+	root := tview.NewFlex().
+		AddItem(left, 0, 3, true).
+		AddItem(right, 0, 2, false)
+
+	ui.focus = []tview.Primitive{ui.input, ui.table, ui.history}
+	ui.app.SetInputCapture(ui.handleKey)
+	ui.app.SetRoot(root, true).SetFocus(ui.input)
+}
+
+// handleKey handles the keys that work in every pane
+func (ui *TUI) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyTab:
+		ui.cycleFocus(1)
+		return nil
+	case tcell.KeyBacktab:
+		ui.cycleFocus(-1)
+		return nil
+	case tcell.KeyCtrlT:
+		ui.toggleDirection()
+		return nil
+	case tcell.KeyEscape:
+		ui.app.Stop()
+		return nil
+	}
+	return event
+}
+
+// cycleFocus moves the focus to the next or previous pane
+func (ui *TUI) cycleFocus(step int) {
+	current := 0
+	for i, p := range ui.focus {
+		if p.HasFocus() {
+			current = i
+		}
+	}
+	next := (current + step + len(ui.focus)) % len(ui.focus)
+	ui.app.SetFocus(ui.focus[next])
+}
+
+// submit handles a line from the input bar: a command or text to translate
+func (ui *TUI) submit(text string) {
+	lower := strings.ToLower(text)
+	switch {
+	case text == "":
+	case lower == "quit" || lower == "exit" || lower == "q":
+		ui.app.Stop()
+	case lower == "toggle" || lower == "t":
+		ui.toggleDirection()
+	case lower == "auto":
+		ui.setDirection("auto")
+	case strings.HasPrefix(lower, "direction "):
+		from, to, err := lang.ParseDirection(strings.TrimSpace(text[10:]))
+		if err != nil {
+			ui.printError(err.Error())
+			return
+		}
+		ui.pair = lang.Direction(from, to)
+		ui.setDirection(ui.pair)
+	default:
+		ui.addHistory(text)
+		ui.translate(text)
+	}
+}
+
+// translate looks text up in the background and shows the result and, for
+// verbs, the conjugations
+func (ui *TUI) translate(text string) {
+	from, to := ui.languages(text)
+	text = lexicon.ExpandShortcuts(from, text)
+	fmt.Fprintf(ui.results, "\n[::b]%s[::-] [::d](%s → %s)[::-]\n", tview.Escape(text), lang.Name(from), lang.Name(to))
+	ui.results.ScrollToEnd()
+
+	go func() {
+		result, correction, err := translator.TranslateWithCorrection(ui.translator, text, from, to)
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() { ui.printError(fmt.Sprintf("Translation error: %v", err)) })
+			return
+		}
+
+		var conjugations conjugation.Table
+		if lang.SupportsConjugation(from) && result.IsVerb {
+			conjugations, _ = ui.translator.GetConjugations(from, result.OriginalText)
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			if correction != nil {
+				fmt.Fprintf(ui.results, "[yellow]Did you mean %s?[-]\n", tview.Escape(correction.Corrected))
+			}
+			fmt.Fprintf(ui.results, "[green]%s[-]\n", tview.Escape(result.Translation))
+			if translator.IsLowConfidence(result) {
+				if suggestions := lexicon.Suggest(from, result.OriginalText, 5); len(suggestions) > 0 {
+					fmt.Fprintf(ui.results, "[::d]Similar words: %s[::-]\n", tview.Escape(strings.Join(suggestions, ", ")))
+				}
+			} else {
+				ui.vocab.Record(result, from, to)
+			}
+			ui.results.ScrollToEnd()
+
+			if len(conjugations) > 0 {
+				ui.showConjugations(from, result.OriginalText, conjugations)
+			}
+		})
+	}()
+}
+
+// showConjugations fills the conjugation panel, starting on the first
+// default tense the verb has
+func (ui *TUI) showConjugations(language, verb string, conjugations conjugation.Table) {
+	ui.language, ui.verb, ui.conjugations = language, verb, conjugations
+	ui.tenses = conjugations.Tenses(language)
+
+	first := 0
+	for i, tense := range ui.tenses {
+		if len(ui.config.DefaultTenses) > 0 && tense == ui.config.DefaultTenses[0] {
+			first = i
+		}
+	}
+
+	ui.tabs.Clear()
+	for i, tense := range ui.tenses {
+		fmt.Fprintf(ui.tabs, `["%d"] %s [""]`, i, translator.FormatTenseName(tense))
+	}
+	ui.selectTense(first)
+}
+
+// selectTense shows the tense at index i in the table, wrapping around
+func (ui *TUI) selectTense(i int) {
+	if len(ui.tenses) == 0 {
+		return
+	}
+	ui.tense = (i + len(ui.tenses)) % len(ui.tenses)
+	ui.tabs.Highlight(strconv.Itoa(ui.tense))
+	ui.tabs.ScrollToHighlight()
+
+	tense := ui.tenses[ui.tense]
+	ui.table.Clear()
+	ui.table.SetCell(0, 0, tview.NewTableCell(ui.verb).SetTextColor(tcell.ColorGreen).SetAttributes(tcell.AttrBold))
+	ui.table.SetCell(0, 1, tview.NewTableCell(translator.FormatTenseName(tense)).SetTextColor(tcell.ColorGreen))
+	for row, person := range lang.RegionPersons(ui.language, ui.config.Region) {
+		form := ui.conjugations.Form(tense, person)
+		if form == "" {
+			form = "-"
+		}
+		ui.table.SetCell(row+1, 0, tview.NewTableCell(person).SetTextColor(tcell.ColorYellow))
+		ui.table.SetCell(row+1, 1, tview.NewTableCell(form).SetExpansion(1))
+	}
+}
+
+// addHistory puts text at the top of the history pane, once
+func (ui *TUI) addHistory(text string) {
+	if matches := ui.history.FindItems(text, "", false, true); len(matches) > 0 {
+		ui.history.RemoveItem(matches[0])
+	}
+	ui.history.InsertItem(0, text, "", 0, nil)
+	ui.history.SetCurrentItem(0)
+}
+
+// printError shows an error in the results pane
+func (ui *TUI) printError(message string) {
+	fmt.Fprintf(ui.results, "[red]%s[-]\n", tview.Escape(message))
+	ui.results.ScrollToEnd()
+}
+
+// languages returns the from and to language codes for text, detecting the
+// direction in auto mode
+func (ui *TUI) languages(text string) (from, to string) {
+	direction := ui.direction
+	if direction == "auto" {
+		direction = ui.pair
+	}
+	from, to, err := lang.ParseDirection(direction)
+	if err != nil {
+		return "es", "en"
+	}
+	if ui.direction == "auto" && lang.Detect(text, from, to) == to {
+		from, to = to, from
+	}
+	return from, to
+}
+
+// toggleDirection cycles through the language pair, its reverse and auto,
+// like Ctrl+T in the REPL
+func (ui *TUI) toggleDirection() {
+	switch ui.direction {
+	case "auto":
+		ui.setDirection(ui.pair)
+	case ui.pair:
+		from, to, _ := lang.ParseDirection(ui.pair)
+		ui.setDirection(lang.Direction(to, from))
+	default:
+		ui.setDirection("auto")
+	}
+}
+
+// setDirection switches direction and shows it in the input bar
+func (ui *TUI) setDirection(direction string) {
+	ui.direction = direction
+	ui.updateLabel()
+}
+
+// updateLabel shows the direction in the input bar's title
+func (ui *TUI) updateLabel() {
+	label := "auto"
+	if ui.direction != "auto" {
+		from, to, _ := lang.ParseDirection(ui.direction)
+		label = lang.Name(from) + " → " + lang.Name(to)
+	}
+	ui.input.SetTitle(" " + label + " ")
+	ui.input.SetLabel("> ")
+}
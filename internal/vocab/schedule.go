@@ -0,0 +1,151 @@
+package vocab
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SM-2 constants: new cards start at defaultEase and ease never drops below
+// minEase, so hard cards still move forward
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+)
+
+// Answer grades on the SM-2 scale of 0 (blackout) to 5 (perfect). Typed
+// answers are graded GradeGood when right and GradeWrong otherwise.
+const (
+	GradeWrong = 1
+	GradeGood  = 4
+)
+
+// matureInterval is the interval in days after which a card counts as learned
+const matureInterval = 21
+
+// Schedule is the SM-2 review state of an entry
+type Schedule struct {
+	Ease        float64   `json:"ease"`
+	Interval    int       `json:"interval"`    // Days until the next review
+	Repetitions int       `json:"repetitions"` // Correct reviews in a row
+	Due         time.Time `json:"due"`
+	Reviews     int       `json:"reviews"`
+	Lapses      int       `json:"lapses"` // Reviews that were wrong
+}
+
+// NewSchedule returns the schedule of a card that is due for its first review
+func NewSchedule(now time.Time) Schedule {
+	return Schedule{Ease: defaultEase, Due: now}
+}
+
+// IsNew reports whether the card has never been reviewed
+func (s Schedule) IsNew() bool {
+	return s.Reviews == 0
+}
+
+// IsDue reports whether the card should be reviewed at now. Entries saved
+// before scheduling existed have no due date and are due right away.
+func (s Schedule) IsDue(now time.Time) bool {
+	return !s.Due.After(now)
+}
+
+// Review applies an answer graded 0-5 at now using SM-2: correct answers
+// grow the interval (1 day, 6 days, then by the ease factor) and wrong ones
+// start the card over. The ease moves with the grade either way.
+func (s *Schedule) Review(grade int, now time.Time) {
+	grade = min(max(grade, 0), 5)
+	if s.Ease == 0 {
+		s.Ease = defaultEase
+	}
+
+	s.Reviews++
+	if grade >= 3 {
+		switch s.Repetitions {
+		case 0:
+			s.Interval = 1
+		case 1:
+			s.Interval = 6
+		default:
+			s.Interval = int(math.Round(float64(s.Interval) * s.Ease))
+		}
+		s.Repetitions++
+	} else {
+		s.Repetitions = 0
+		s.Interval = 1
+		s.Lapses++
+	}
+
+	miss := float64(5 - grade)
+	s.Ease = max(s.Ease+0.1-miss*(0.08+miss*0.02), minEase)
+	s.Due = now.AddDate(0, 0, s.Interval)
+}
+
+// Due returns the entries due for review, most overdue first
+func (s *Store) Due() []*Entry {
+	now := s.now()
+	var due []*Entry
+	for _, e := range s.entries {
+		if e.Schedule.IsDue(now) {
+			due = append(due, e)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Schedule.Due.Before(due[j].Schedule.Due)
+	})
+	return due
+}
+
+// Stats summarizes the vocabulary's review state
+type Stats struct {
+	Total     int
+	New       int // Never reviewed
+	Due       int // Due now, including new cards
+	Learning  int // Reviewed, interval under three weeks
+	Mature    int // Interval of three weeks or more
+	Reviews   int
+	Lapses    int
+	Retention float64 // Share of reviews answered correctly, 0 without reviews
+	Upcoming  []int   // Cards coming due on each day, starting later today
+}
+
+// Stats returns review statistics, with the review load for the given
+// number of days
+func (s *Store) Stats(days int) Stats {
+	now := s.now()
+	today := startOfDay(now)
+	stats := Stats{Total: len(s.entries), Upcoming: make([]int, days)}
+
+	for _, e := range s.entries {
+		sched := e.Schedule
+		switch {
+		case sched.IsNew():
+			stats.New++
+		case sched.Interval >= matureInterval:
+			stats.Mature++
+		default:
+			stats.Learning++
+		}
+		stats.Reviews += sched.Reviews
+		stats.Lapses += sched.Lapses
+
+		if sched.IsDue(now) {
+			stats.Due++
+			continue
+		}
+		day := int(math.Round(startOfDay(sched.Due).Sub(today).Hours() / 24))
+		if day >= 0 && day < days {
+			stats.Upcoming[day]++
+		}
+	}
+
+	if stats.Reviews > 0 {
+		stats.Retention = float64(stats.Reviews-stats.Lapses) / float64(stats.Reviews)
+	}
+	return stats
+}
+
+// startOfDay returns midnight of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
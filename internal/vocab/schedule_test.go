@@ -0,0 +1,167 @@
+package vocab
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"tr/internal/translator"
+)
+
+// start is the fixed time the tests' clocks begin at
+var start = time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func TestReviewIntervals(t *testing.T) {
+	tests := []struct {
+		name      string
+		grades    []int
+		intervals []int // After each review
+		ease      float64
+	}{
+		{"good answers", []int{GradeGood, GradeGood, GradeGood, GradeGood, GradeGood}, []int{1, 6, 15, 38, 95}, 2.5},
+		{"perfect answers raise ease", []int{5, 5, 5}, []int{1, 6, 16}, 2.8},
+		{"hard answers lower ease", []int{3, 3, 3}, []int{1, 6, 13}, 2.08},
+		{"lapse resets", []int{GradeGood, GradeGood, GradeGood, GradeWrong}, []int{1, 6, 15, 1}, 1.96},
+		{"relearning after a lapse", []int{GradeGood, GradeGood, GradeWrong, GradeGood, GradeGood, GradeGood}, []int{1, 6, 1, 1, 6, 12}, 1.96},
+		{"ease floor", []int{GradeWrong, GradeWrong, GradeWrong, GradeWrong}, []int{1, 1, 1, 1}, minEase},
+		{"grade clamped to 5", []int{9}, []int{1}, 2.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSchedule(start)
+			now := start
+			var intervals []int
+			for _, grade := range tt.grades {
+				s.Review(grade, now)
+				intervals = append(intervals, s.Interval)
+				if want := now.AddDate(0, 0, s.Interval); !s.Due.Equal(want) {
+					t.Errorf("due = %v, want %v", s.Due, want)
+				}
+				now = s.Due
+			}
+
+			if !slices.Equal(intervals, tt.intervals) {
+				t.Errorf("intervals = %v, want %v", intervals, tt.intervals)
+			}
+			if math.Abs(s.Ease-tt.ease) > 1e-9 {
+				t.Errorf("ease = %.4f, want %.4f", s.Ease, tt.ease)
+			}
+			if s.Reviews != len(tt.grades) {
+				t.Errorf("reviews = %d, want %d", s.Reviews, len(tt.grades))
+			}
+		})
+	}
+}
+
+func TestReviewCounts(t *testing.T) {
+	s := NewSchedule(start)
+	if !s.IsNew() || !s.IsDue(start) {
+		t.Fatalf("new schedule: IsNew %v, IsDue %v; want both", s.IsNew(), s.IsDue(start))
+	}
+
+	s.Review(GradeGood, start)
+	s.Review(GradeWrong, start)
+	if s.IsNew() || s.Repetitions != 0 || s.Lapses != 1 || s.Reviews != 2 {
+		t.Errorf("after good and wrong: %+v, want 2 reviews, 1 lapse, 0 repetitions", s)
+	}
+	if s.IsDue(start.Add(23*time.Hour)) || !s.IsDue(start.AddDate(0, 0, 1)) {
+		t.Error("card reviewed wrong should be due again in one day")
+	}
+
+	// Entries saved before scheduling have a zero schedule
+	var old Schedule
+	old.Review(GradeGood, start)
+	if old.Ease != defaultEase || old.Interval != 1 {
+		t.Errorf("zero schedule after review = %+v, want default ease and 1 day", old)
+	}
+}
+
+// testStore returns an in-memory store whose clock the test moves
+func testStore() (*Store, *time.Time) {
+	now := start
+	return NewStore("", func() time.Time { return now }), &now
+}
+
+func record(s *Store, word string) *Entry {
+	s.Record(&translator.TranslationResult{OriginalText: word, Translation: word + "!"}, "es", "en")
+	return s.Find(word, "es", "en")
+}
+
+func TestDueOrder(t *testing.T) {
+	s, now := testStore()
+
+	a := record(s, "uno")
+	*now = now.Add(time.Hour)
+	record(s, "dos")
+	*now = now.Add(time.Hour)
+	c := record(s, "tres")
+	d := record(s, "cuatro")
+
+	// Overdue by different amounts: uno and tres were due days ago, dos is new
+	a.Schedule.Due = start.AddDate(0, 0, -2)
+	c.Schedule.Due = start.AddDate(0, 0, -5)
+	d.Schedule.Review(GradeGood, *now) // Due tomorrow
+
+	var words []string
+	for _, e := range s.Due() {
+		words = append(words, e.Word)
+	}
+	if want := []string{"tres", "uno", "dos"}; !slices.Equal(words, want) {
+		t.Errorf("Due = %v, want %v", words, want)
+	}
+
+	*now = now.AddDate(0, 0, 1)
+	if n := len(s.Due()); n != 4 {
+		t.Errorf("Due a day later = %d entries, want 4", n)
+	}
+}
+
+func TestStats(t *testing.T) {
+	s, now := testStore()
+
+	record(s, "nuevo") // New and due
+
+	later := record(s, "luego") // Due later today
+	later.Schedule.Review(GradeGood, *now)
+	later.Schedule.Due = now.Add(6 * time.Hour)
+
+	tomorrow := record(s, "mañana") // Due tomorrow, reviewed wrong once
+	tomorrow.Schedule.Review(GradeGood, *now)
+	tomorrow.Schedule.Review(GradeWrong, *now)
+
+	week := record(s, "semana") // Due in six days
+	week.Schedule.Review(GradeGood, now.AddDate(0, 0, -1))
+	week.Schedule.Review(GradeGood, now.AddDate(0, 0, 0))
+
+	mature := record(s, "viejo") // Interval past three weeks, due in 30 days
+	mature.Schedule = Schedule{Ease: 2.5, Interval: 30, Repetitions: 4, Reviews: 4, Due: now.AddDate(0, 0, 30)}
+
+	stats := s.Stats(7)
+	if stats.Total != 5 || stats.New != 1 || stats.Due != 1 || stats.Learning != 3 || stats.Mature != 1 {
+		t.Errorf("counts = total %d, new %d, due %d, learning %d, mature %d; want 5, 1, 1, 3, 1",
+			stats.Total, stats.New, stats.Due, stats.Learning, stats.Mature)
+	}
+	if stats.Reviews != 9 || stats.Lapses != 1 {
+		t.Errorf("reviews, lapses = %d, %d; want 9, 1", stats.Reviews, stats.Lapses)
+	}
+	if want := 8.0 / 9; math.Abs(stats.Retention-want) > 1e-9 {
+		t.Errorf("retention = %.3f, want %.3f", stats.Retention, want)
+	}
+	if want := []int{1, 1, 0, 0, 0, 0, 1}; !slices.Equal(stats.Upcoming, want) {
+		t.Errorf("upcoming = %v, want %v", stats.Upcoming, want)
+	}
+
+	// Late in the evening, later today and tomorrow stay separate buckets
+	*now = time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC)
+	later.Schedule.Due = time.Date(2025, time.March, 10, 23, 45, 0, 0, time.UTC)
+	tomorrow.Schedule.Due = time.Date(2025, time.March, 11, 0, 15, 0, 0, time.UTC)
+	if got := s.Stats(2).Upcoming; !slices.Equal(got, []int{1, 1}) {
+		t.Errorf("upcoming around midnight = %v, want [1 1]", got)
+	}
+
+	if empty := NewStore("", time.Now).Stats(3); empty.Retention != 0 || len(empty.Upcoming) != 3 {
+		t.Errorf("empty stats = %+v, want no retention and 3 days", empty)
+	}
+}
@@ -0,0 +1,139 @@
+package vocab
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"tr/pkg/lang"
+	"tr/pkg/lexicon"
+
+	"github.com/fatih/color"
+)
+
+// Asker shows a prompt and reads the user's answer. It returns false when
+// the user wants to stop, e.g. on end of input.
+type Asker func(prompt string) (string, bool)
+
+// Card is one flashcard: a prompt in one language and the expected answer
+type Card struct {
+	Entry    *Entry
+	Question string
+	Answer   string
+	Reverse  bool // Asks for the original word from its translation
+}
+
+// NewCard builds a flashcard for an entry in the given direction
+func NewCard(e *Entry, reverse bool) Card {
+	if reverse {
+		return Card{Entry: e, Question: e.Translation, Answer: e.Word, Reverse: true}
+	}
+	return Card{Entry: e, Question: e.Word, Answer: e.Translation}
+}
+
+// Prompt returns the question shown for a card, e.g. "hablar (Spanish → English): "
+func (c Card) Prompt() string {
+	from, to := c.Entry.From, c.Entry.To
+	if c.Reverse {
+		from, to = to, from
+	}
+	return fmt.Sprintf("%s (%s → %s): ", c.Question, lang.Name(from), lang.Name(to))
+}
+
+// Check reports whether answer matches expected, ignoring case, accents,
+// punctuation and a leading "to " on English verbs. Expected answers with
+// several meanings ("dog, hound" or "car/automobile") accept any of them.
+func Check(answer, expected string) bool {
+	given := normalize(answer)
+	if given == "" {
+		return false
+	}
+
+	for _, option := range strings.FieldsFunc(expected, func(r rune) bool {
+		return r == ',' || r == '/' || r == ';'
+	}) {
+		if normalize(option) == given {
+			return true
+		}
+	}
+	return normalize(expected) == given
+}
+
+// normalize folds text for comparison
+func normalize(text string) string {
+	text = lexicon.Fold(strings.TrimSpace(text))
+	text = strings.Trim(text, ".!?¡¿\"' ")
+	text = strings.TrimPrefix(text, "to ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Study quizzes up to count flashcards from the store in random directions,
+// least-known words first, and records the results. It returns the number
+// of correct answers and cards asked.
+func Study(s *Store, ask Asker, out io.Writer, count int, rnd *rand.Rand) (correct, asked int) {
+	entries := s.Entries()
+	rnd.Shuffle(len(entries), func(i, j int) { entries[i], entries[j] = entries[j], entries[i] })
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Correct-entries[i].Incorrect < entries[j].Correct-entries[j].Incorrect
+	})
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+
+	correct, asked = quiz(entries, ask, out, rnd, func(e *Entry, right bool) {
+		if right {
+			e.Correct++
+		} else {
+			e.Incorrect++
+		}
+	})
+
+	s.Save()
+	return correct, asked
+}
+
+// Review quizzes up to count cards that are due, most overdue first, and
+// reschedules each one with SM-2. It returns the number of correct answers
+// and cards asked.
+func Review(s *Store, ask Asker, out io.Writer, count int, rnd *rand.Rand) (correct, asked int) {
+	entries := s.Due()
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+
+	correct, asked = quiz(entries, ask, out, rnd, func(e *Entry, right bool) {
+		grade := GradeWrong
+		if right {
+			grade = GradeGood
+		}
+		e.Schedule.Review(grade, s.now())
+	})
+
+	s.Save()
+	return correct, asked
+}
+
+// quiz asks a flashcard for each entry in a random direction until the user
+// stops, reporting each answer to record
+func quiz(entries []*Entry, ask Asker, out io.Writer, rnd *rand.Rand, record func(e *Entry, right bool)) (correct, asked int) {
+	for _, e := range entries {
+		card := NewCard(e, rnd.Intn(2) == 1)
+		answer, ok := ask(card.Prompt())
+		if !ok {
+			break
+		}
+
+		asked++
+		right := Check(answer, card.Answer)
+		if right {
+			correct++
+			fmt.Fprintln(out, color.New(color.FgGreen).Sprint("✓ Correct!"))
+		} else {
+			fmt.Fprintln(out, color.New(color.FgRed).Sprintf("✗ The answer is: %s", card.Answer))
+		}
+		record(e, right)
+	}
+	return correct, asked
+}
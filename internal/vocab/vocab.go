@@ -0,0 +1,139 @@
+package vocab
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"tr/internal/config"
+	"tr/internal/translator"
+)
+
+// Entry is a word or phrase that was looked up, with its translation
+type Entry struct {
+	Word        string    `json:"word"`
+	Translation string    `json:"translation"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	IsVerb      bool      `json:"is_verb,omitempty"`
+	Lookups     int       `json:"lookups"`
+	Correct     int       `json:"correct"`   // Study answers that were right
+	Incorrect   int       `json:"incorrect"` // Study answers that were wrong
+	Added       time.Time `json:"added"`
+	LastSeen    time.Time `json:"last_seen"`
+	Schedule    Schedule  `json:"schedule"`
+}
+
+// Store holds the vocabulary collected from lookups, persisted as JSON.
+// A store with an empty path is kept in memory only.
+type Store struct {
+	path    string
+	entries []*Entry
+	now     func() time.Time
+}
+
+// Open loads the vocabulary from ~/.config/tr/vocab.json, starting empty
+// if the file is missing or unreadable
+func Open() *Store {
+	return NewStore(filepath.Join(config.Dir(), "vocab.json"), time.Now)
+}
+
+// NewStore loads the vocabulary at path, using now as the clock for lookups
+// and review scheduling. An empty path keeps the store in memory.
+func NewStore(path string, now func() time.Time) *Store {
+	s := &Store{path: path, now: now}
+	if path != "" {
+		s.load()
+	}
+	return s
+}
+
+// Now returns the current time on the store's clock
+func (s *Store) Now() time.Time {
+	return s.now()
+}
+
+// load reads saved entries, silently ignoring a missing or invalid file
+func (s *Store) load() {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	json.Unmarshal(data, &s.entries)
+}
+
+// Save writes the vocabulary to disk
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Record adds a translation to the vocabulary, or counts another lookup of
+// a word already in it, and saves the store. Failures are silent since the
+// vocabulary is optional.
+func (s *Store) Record(result *translator.TranslationResult, from, to string) {
+	word := strings.TrimSpace(result.OriginalText)
+	translation := strings.TrimSpace(result.Translation)
+	if word == "" || translation == "" {
+		return
+	}
+
+	now := s.now()
+	if e := s.Find(word, from, to); e != nil {
+		e.Translation = translation
+		e.Lookups++
+		e.LastSeen = now
+	} else {
+		s.entries = append(s.entries, &Entry{
+			Word:        word,
+			Translation: translation,
+			From:        from,
+			To:          to,
+			IsVerb:      result.IsVerb,
+			Lookups:     1,
+			Added:       now,
+			LastSeen:    now,
+			Schedule:    NewSchedule(now),
+		})
+	}
+
+	s.Save()
+}
+
+// Find returns the entry for a word in a direction, ignoring case
+func (s *Store) Find(word, from, to string) *Entry {
+	for _, e := range s.entries {
+		if e.From == from && e.To == to && strings.EqualFold(e.Word, word) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Entries returns all entries, most recently seen first
+func (s *Store) Entries() []*Entry {
+	entries := append([]*Entry(nil), s.entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastSeen.After(entries[j].LastSeen)
+	})
+	return entries
+}
+
+// Len returns the number of entries
+func (s *Store) Len() int {
+	return len(s.entries)
+}
@@ -0,0 +1,134 @@
+// Package conjugation models verb conjugation tables. It conjugates
+// Portuguese, French and Italian verbs offline and adapts Spanish tables to
+// regional varieties.
+package conjugation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"tr/pkg/lang"
+)
+
+// Table holds a verb's forms by tense and person, e.g.
+// table["preterite"]["yo"] = "hablé". Tense and person names are those of
+// lang.Tenses and lang.Persons.
+type Table map[string]map[string]string
+
+// Form returns the form for a tense and person, or "" if the table lacks it
+func (t Table) Form(tense, person string) string {
+	return t[tense][person]
+}
+
+// Tenses returns the tenses in the table, in the language's display order
+// followed by any others alphabetically
+func (t Table) Tenses(code string) []string {
+	var tenses []string
+	for _, tense := range lang.Tenses(code) {
+		if len(t[tense]) > 0 {
+			tenses = append(tenses, tense)
+		}
+	}
+
+	var extra []string
+	for tense, forms := range t {
+		if len(forms) > 0 && !slices.Contains(tenses, tense) {
+			extra = append(extra, tense)
+		}
+	}
+	sort.Strings(extra)
+	return append(tenses, extra...)
+}
+
+// Conjugator produces conjugation tables (tense -> person -> form) for a language
+type Conjugator interface {
+	Conjugate(verb string) (Table, error)
+}
+
+// conjugators holds the offline, rule-based conjugators by language code.
+// Spanish is served by SpanishDict in the translator package instead.
+var conjugators = map[string]Conjugator{
+	"pt": portuguese{},
+	"fr": french{},
+	"it": italian{},
+}
+
+// For returns the offline conjugator for a language, if there is one
+func For(code string) (Conjugator, bool) {
+	c, ok := conjugators[code]
+	return c, ok
+}
+
+// verbEndings lists the infinitive endings used to spot verbs per language
+var verbEndings = map[string][]string{
+	"es": {"ar", "er", "ir", "ír"},
+	"pt": {"ar", "er", "ir"},
+	"fr": {"er", "ir", "re", "oir"},
+	"it": {"are", "ere", "ire"},
+}
+
+// IsLikelyVerb checks if a word is likely an infinitive in the given language
+func IsLikelyVerb(code, word string) bool {
+	if !lang.SupportsConjugation(code) {
+		return false
+	}
+
+	word = strings.ToLower(word)
+	for _, ending := range verbEndings[code] {
+		if strings.HasSuffix(word, ending) {
+			return true
+		}
+	}
+	return false
+}
+
+// irregular describes a verb whose forms deviate from the regular patterns
+type irregular struct {
+	forms map[string][]string // tense -> forms in person order, overriding the regular ones
+	stem  string              // future and conditional stem, if irregular
+}
+
+// attach appends each ending to stem, letting fix adjust the spelling at the joint
+func attach(stem string, endings []string, fix func(stem, ending string) string) []string {
+	forms := make([]string, len(endings))
+	for i, ending := range endings {
+		if fix != nil {
+			forms[i] = fix(stem, ending)
+		} else {
+			forms[i] = stem + ending
+		}
+	}
+	return forms
+}
+
+// table converts per-tense form lists into tense -> person -> form
+func table(code string, forms map[string][]string) Table {
+	persons := lang.Persons(code)
+	conjugations := make(Table)
+
+	for tense, list := range forms {
+		conjugations[tense] = make(map[string]string)
+		for i, form := range list {
+			if i < len(persons) {
+				conjugations[tense][persons[i]] = form
+			}
+		}
+	}
+
+	return conjugations
+}
+
+// notAVerb builds the error returned for words that are not infinitives
+func notAVerb(code, verb string) error {
+	return fmt.Errorf("%q does not look like a %s infinitive", verb, lang.Name(code))
+}
+
+// trimSuffix removes ending from verb, reporting whether it was present
+func trimSuffix(verb, ending string) (string, bool) {
+	if strings.HasSuffix(verb, ending) && len(verb) > len(ending) {
+		return strings.TrimSuffix(verb, ending), true
+	}
+	return "", false
+}
@@ -0,0 +1,205 @@
+package conjugation
+
+import "strings"
+
+// french conjugates regular -er/-ir/-re verbs and common irregulars
+type french struct{}
+
+// frenchImperfect holds the imperfect endings shared by every verb class
+var frenchImperfect = []string{"ais", "ais", "ait", "ions", "iez", "aient"}
+
+// frenchEndings holds the regular endings per verb class and tense
+var frenchEndings = map[string]map[string][]string{
+	"er": {
+		"present":             {"e", "es", "e", "ons", "ez", "ent"},
+		"preterite":           {"ai", "as", "a", "âmes", "âtes", "èrent"},
+		"imperfect":           frenchImperfect,
+		"present_subjunctive": {"e", "es", "e", "ions", "iez", "ent"},
+	},
+	"ir": {
+		"present":             {"is", "is", "it", "issons", "issez", "issent"},
+		"preterite":           {"is", "is", "it", "îmes", "îtes", "irent"},
+		"imperfect":           {"issais", "issais", "issait", "issions", "issiez", "issaient"},
+		"present_subjunctive": {"isse", "isses", "isse", "issions", "issiez", "issent"},
+	},
+	"partir": {
+		"present":             {"s", "s", "t", "ons", "ez", "ent"},
+		"preterite":           {"is", "is", "it", "îmes", "îtes", "irent"},
+		"imperfect":           frenchImperfect,
+		"present_subjunctive": {"e", "es", "e", "ions", "iez", "ent"},
+	},
+	"re": {
+		"present":             {"s", "s", "", "ons", "ez", "ent"},
+		"preterite":           {"is", "is", "it", "îmes", "îtes", "irent"},
+		"imperfect":           frenchImperfect,
+		"present_subjunctive": {"e", "es", "e", "ions", "iez", "ent"},
+	},
+}
+
+// frenchPartir lists the -ir verbs conjugated like partir, without the
+// -iss- of finir and with a shorter stem in the present singular
+var frenchPartir = map[string]bool{
+	"partir": true,
+	"dormir": true,
+	"sortir": true,
+	"servir": true,
+	"sentir": true,
+	"mentir": true,
+}
+
+// Future and conditional endings are added to the future stem
+var (
+	frenchFuture      = []string{"ai", "as", "a", "ons", "ez", "ont"}
+	frenchConditional = []string{"ais", "ais", "ait", "ions", "iez", "aient"}
+)
+
+// frenchIrregulars lists the core irregular verbs
+var frenchIrregulars = map[string]irregular{
+	"être": {stem: "ser", forms: map[string][]string{
+		"present":             {"suis", "es", "est", "sommes", "êtes", "sont"},
+		"preterite":           {"fus", "fus", "fut", "fûmes", "fûtes", "furent"},
+		"imperfect":           attach("ét", frenchImperfect, nil),
+		"present_subjunctive": {"sois", "sois", "soit", "soyons", "soyez", "soient"},
+	}},
+	"avoir": {stem: "aur", forms: map[string][]string{
+		"present":             {"ai", "as", "a", "avons", "avez", "ont"},
+		"preterite":           {"eus", "eus", "eut", "eûmes", "eûtes", "eurent"},
+		"imperfect":           attach("av", frenchImperfect, nil),
+		"present_subjunctive": {"aie", "aies", "ait", "ayons", "ayez", "aient"},
+	}},
+	"aller": {stem: "ir", forms: map[string][]string{
+		"present":             {"vais", "vas", "va", "allons", "allez", "vont"},
+		"present_subjunctive": {"aille", "ailles", "aille", "allions", "alliez", "aillent"},
+	}},
+	"faire": {stem: "fer", forms: map[string][]string{
+		"present":             {"fais", "fais", "fait", "faisons", "faites", "font"},
+		"preterite":           {"fis", "fis", "fit", "fîmes", "fîtes", "firent"},
+		"imperfect":           attach("fais", frenchImperfect, nil),
+		"present_subjunctive": {"fasse", "fasses", "fasse", "fassions", "fassiez", "fassent"},
+	}},
+	"dire": {stem: "dir", forms: map[string][]string{
+		"present":             {"dis", "dis", "dit", "disons", "dites", "disent"},
+		"preterite":           {"dis", "dis", "dit", "dîmes", "dîtes", "dirent"},
+		"imperfect":           attach("dis", frenchImperfect, nil),
+		"present_subjunctive": {"dise", "dises", "dise", "disions", "disiez", "disent"},
+	}},
+	"prendre": {stem: "prendr", forms: map[string][]string{
+		"present":             {"prends", "prends", "prend", "prenons", "prenez", "prennent"},
+		"preterite":           {"pris", "pris", "prit", "prîmes", "prîtes", "prirent"},
+		"imperfect":           attach("pren", frenchImperfect, nil),
+		"present_subjunctive": {"prenne", "prennes", "prenne", "prenions", "preniez", "prennent"},
+	}},
+	"venir": {stem: "viendr", forms: map[string][]string{
+		"present":             {"viens", "viens", "vient", "venons", "venez", "viennent"},
+		"preterite":           {"vins", "vins", "vint", "vînmes", "vîntes", "vinrent"},
+		"imperfect":           attach("ven", frenchImperfect, nil),
+		"present_subjunctive": {"vienne", "viennes", "vienne", "venions", "veniez", "viennent"},
+	}},
+	"tenir": {stem: "tiendr", forms: map[string][]string{
+		"present":             {"tiens", "tiens", "tient", "tenons", "tenez", "tiennent"},
+		"preterite":           {"tins", "tins", "tint", "tînmes", "tîntes", "tinrent"},
+		"imperfect":           attach("ten", frenchImperfect, nil),
+		"present_subjunctive": {"tienne", "tiennes", "tienne", "tenions", "teniez", "tiennent"},
+	}},
+	"pouvoir": {stem: "pourr", forms: map[string][]string{
+		"present":             {"peux", "peux", "peut", "pouvons", "pouvez", "peuvent"},
+		"preterite":           {"pus", "pus", "put", "pûmes", "pûtes", "purent"},
+		"imperfect":           attach("pouv", frenchImperfect, nil),
+		"present_subjunctive": {"puisse", "puisses", "puisse", "puissions", "puissiez", "puissent"},
+	}},
+	"vouloir": {stem: "voudr", forms: map[string][]string{
+		"present":             {"veux", "veux", "veut", "voulons", "voulez", "veulent"},
+		"preterite":           {"voulus", "voulus", "voulut", "voulûmes", "voulûtes", "voulurent"},
+		"imperfect":           attach("voul", frenchImperfect, nil),
+		"present_subjunctive": {"veuille", "veuilles", "veuille", "voulions", "vouliez", "veuillent"},
+	}},
+	"savoir": {stem: "saur", forms: map[string][]string{
+		"present":             {"sais", "sais", "sait", "savons", "savez", "savent"},
+		"preterite":           {"sus", "sus", "sut", "sûmes", "sûtes", "surent"},
+		"imperfect":           attach("sav", frenchImperfect, nil),
+		"present_subjunctive": {"sache", "saches", "sache", "sachions", "sachiez", "sachent"},
+	}},
+	"devoir": {stem: "devr", forms: map[string][]string{
+		"present":             {"dois", "dois", "doit", "devons", "devez", "doivent"},
+		"preterite":           {"dus", "dus", "dut", "dûmes", "dûtes", "durent"},
+		"imperfect":           attach("dev", frenchImperfect, nil),
+		"present_subjunctive": {"doive", "doives", "doive", "devions", "deviez", "doivent"},
+	}},
+	"voir": {stem: "verr", forms: map[string][]string{
+		"present":             {"vois", "vois", "voit", "voyons", "voyez", "voient"},
+		"preterite":           {"vis", "vis", "vit", "vîmes", "vîtes", "virent"},
+		"imperfect":           attach("voy", frenchImperfect, nil),
+		"present_subjunctive": {"voie", "voies", "voie", "voyions", "voyiez", "voient"},
+	}},
+}
+
+// Conjugate builds the conjugation table for a French infinitive
+func (french) Conjugate(verb string) (Table, error) {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+
+	irr, isIrregular := frenchIrregulars[verb]
+
+	var class, stem string
+	if !strings.HasSuffix(verb, "oir") {
+		for _, ending := range []string{"er", "ir", "re"} {
+			if s, ok := trimSuffix(verb, ending); ok {
+				class, stem = ending, s
+				break
+			}
+		}
+	}
+
+	if class == "" && !isIrregular {
+		return nil, notAVerb("fr", verb)
+	}
+	if class == "ir" && frenchPartir[verb] {
+		class = "partir"
+	}
+
+	forms := make(map[string][]string)
+	for tense, endings := range frenchEndings[class] {
+		forms[tense] = attach(stem, endings, frenchSpelling(class))
+	}
+
+	// The partir class drops the last consonant of the stem in the present
+	// singular, e.g. dorm- -> dors, dort
+	if class == "partir" {
+		short := stem[:len(stem)-1]
+		for i, ending := range frenchEndings[class]["present"][:3] {
+			forms["present"][i] = short + ending
+		}
+	}
+
+	// The future stem is the infinitive, minus the final e of -re verbs
+	future := strings.TrimSuffix(verb, "e")
+	if irr.stem != "" {
+		future = irr.stem
+	}
+	forms["future"] = attach(future, frenchFuture, nil)
+	forms["conditional"] = attach(future, frenchConditional, nil)
+
+	for tense, list := range irr.forms {
+		forms[tense] = list
+	}
+
+	return table("fr", forms), nil
+}
+
+// frenchSpelling keeps -ger and -cer verbs soft before a and o,
+// e.g. manger -> mangeons, commencer -> commençais
+func frenchSpelling(class string) func(stem, ending string) string {
+	return func(stem, ending string) string {
+		hard := strings.HasPrefix(ending, "a") || strings.HasPrefix(ending, "â") || strings.HasPrefix(ending, "o")
+		if class != "er" || !hard {
+			return stem + ending
+		}
+
+		switch {
+		case strings.HasSuffix(stem, "g"):
+			return stem + "e" + ending
+		case strings.HasSuffix(stem, "c"):
+			return strings.TrimSuffix(stem, "c") + "ç" + ending
+		}
+		return stem + ending
+	}
+}
@@ -0,0 +1,53 @@
+package conjugation
+
+import (
+	"slices"
+	"testing"
+
+	"tr/pkg/lang"
+)
+
+func TestFrench(t *testing.T) {
+	tests := []struct {
+		verb  string
+		tense string
+		want  []string // je, tu, il/elle, nous, vous, ils
+	}{
+		// finir keeps -iss-
+		{"finir", "present", []string{"finis", "finis", "finit", "finissons", "finissez", "finissent"}},
+		{"finir", "imperfect", []string{"finissais", "finissais", "finissait", "finissions", "finissiez", "finissaient"}},
+
+		// partir and dormir drop the stem's last consonant in the singular
+		{"partir", "present", []string{"pars", "pars", "part", "partons", "partez", "partent"}},
+		{"dormir", "present", []string{"dors", "dors", "dort", "dormons", "dormez", "dorment"}},
+		{"dormir", "imperfect", []string{"dormais", "dormais", "dormait", "dormions", "dormiez", "dormaient"}},
+		{"sortir", "present_subjunctive", []string{"sorte", "sortes", "sorte", "sortions", "sortiez", "sortent"}},
+		{"servir", "present", []string{"sers", "sers", "sert", "servons", "servez", "servent"}},
+		{"sentir", "preterite", []string{"sentis", "sentis", "sentit", "sentîmes", "sentîtes", "sentirent"}},
+		{"mentir", "future", []string{"mentirai", "mentiras", "mentira", "mentirons", "mentirez", "mentiront"}},
+
+		{"tenir", "present", []string{"tiens", "tiens", "tient", "tenons", "tenez", "tiennent"}},
+		{"tenir", "future", []string{"tiendrai", "tiendras", "tiendra", "tiendrons", "tiendrez", "tiendront"}},
+		{"venir", "present", []string{"viens", "viens", "vient", "venons", "venez", "viennent"}},
+
+		{"manger", "present", []string{"mange", "manges", "mange", "mangeons", "mangez", "mangent"}},
+		{"vendre", "present", []string{"vends", "vends", "vend", "vendons", "vendez", "vendent"}},
+	}
+
+	fr, _ := For("fr")
+	for _, tt := range tests {
+		table, err := fr.Conjugate(tt.verb)
+		if err != nil {
+			t.Errorf("Conjugate(%q): %v", tt.verb, err)
+			continue
+		}
+
+		var got []string
+		for _, person := range lang.Persons("fr") {
+			got = append(got, table.Form(tt.tense, person))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s %s = %v, want %v", tt.verb, tt.tense, got, tt.want)
+		}
+	}
+}
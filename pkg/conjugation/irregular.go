@@ -0,0 +1,69 @@
+package conjugation
+
+import "tr/pkg/lang"
+
+// spanishEndings holds the regular Spanish endings per verb class and tense
+// in person order. Future and conditional endings go on the infinitive.
+var spanishEndings = map[string]map[string][]string{
+	"ar": {
+		"present":   {"o", "as", "a", "amos", "áis", "an"},
+		"preterite": {"é", "aste", "ó", "amos", "asteis", "aron"},
+		"imperfect": {"aba", "abas", "aba", "ábamos", "abais", "aban"},
+	},
+	"er": {
+		"present":   {"o", "es", "e", "emos", "éis", "en"},
+		"preterite": {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		"imperfect": {"ía", "ías", "ía", "íamos", "íais", "ían"},
+	},
+	"ir": {
+		"present":   {"o", "es", "e", "imos", "ís", "en"},
+		"preterite": {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		"imperfect": {"ía", "ías", "ía", "íamos", "íais", "ían"},
+	},
+}
+
+var (
+	spanishFuture      = []string{"é", "ás", "á", "emos", "éis", "án"}
+	spanishConditional = []string{"ía", "ías", "ía", "íamos", "íais", "ían"}
+)
+
+// irregularsByLanguage holds the irregular verbs of the rule-based conjugators
+var irregularsByLanguage = map[string]map[string]irregular{
+	"pt": portugueseIrregulars,
+	"fr": frenchIrregulars,
+	"it": italianIrregulars,
+}
+
+// IsIrregular reports whether a verb has irregular forms. Spanish tables are
+// compared against the regular endings of the simple indicative tenses, so
+// spelling changes such as busqué or leyó count too; other languages use
+// the irregulars their conjugators know about.
+func IsIrregular(code, verb string, conjugations Table) bool {
+	if code != "es" {
+		_, ok := irregularsByLanguage[code][verb]
+		return ok
+	}
+
+	stem, class := spanishClass(verb)
+	if class == "" {
+		return false // Reflexive or not an infinitive
+	}
+
+	regular := map[string][]string{
+		"future":      attach(verb, spanishFuture, nil),
+		"conditional": attach(verb, spanishConditional, nil),
+	}
+	for tense, endings := range spanishEndings[class] {
+		regular[tense] = attach(stem, endings, nil)
+	}
+
+	persons := lang.Persons("es")
+	for tense, forms := range regular {
+		for i, person := range persons {
+			if form, ok := conjugations[tense][person]; ok && form != forms[i] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package conjugation
+
+import "strings"
+
+// italian conjugates regular -are/-ere/-ire verbs and common irregulars
+type italian struct{}
+
+// italianEndings holds the regular endings per verb class and tense.
+// "isc" covers -ire verbs like finire that insert -isc- in the present.
+var italianEndings = map[string]map[string][]string{
+	"are": {
+		"present":             {"o", "i", "a", "iamo", "ate", "ano"},
+		"preterite":           {"ai", "asti", "ò", "ammo", "aste", "arono"},
+		"imperfect":           {"avo", "avi", "ava", "avamo", "avate", "avano"},
+		"present_subjunctive": {"i", "i", "i", "iamo", "iate", "ino"},
+	},
+	"ere": {
+		"present":             {"o", "i", "e", "iamo", "ete", "ono"},
+		"preterite":           {"ei", "esti", "é", "emmo", "este", "erono"},
+		"imperfect":           {"evo", "evi", "eva", "evamo", "evate", "evano"},
+		"present_subjunctive": {"a", "a", "a", "iamo", "iate", "ano"},
+	},
+	"ire": {
+		"present":             {"o", "i", "e", "iamo", "ite", "ono"},
+		"preterite":           {"ii", "isti", "ì", "immo", "iste", "irono"},
+		"imperfect":           {"ivo", "ivi", "iva", "ivamo", "ivate", "ivano"},
+		"present_subjunctive": {"a", "a", "a", "iamo", "iate", "ano"},
+	},
+	"isc": {
+		"present":             {"isco", "isci", "isce", "iamo", "ite", "iscono"},
+		"preterite":           {"ii", "isti", "ì", "immo", "iste", "irono"},
+		"imperfect":           {"ivo", "ivi", "iva", "ivamo", "ivate", "ivano"},
+		"present_subjunctive": {"isca", "isca", "isca", "iamo", "iate", "iscano"},
+	},
+}
+
+// Future and conditional endings are added to the future stem
+var (
+	italianFuture      = []string{"ò", "ai", "à", "emo", "ete", "anno"}
+	italianConditional = []string{"ei", "esti", "ebbe", "emmo", "este", "ebbero"}
+)
+
+// italianISC lists common -ire verbs conjugated like finire
+var italianISC = map[string]bool{
+	"capire": true, "finire": true, "preferire": true, "pulire": true, "costruire": true,
+	"spedire": true, "suggerire": true, "unire": true, "colpire": true, "fornire": true,
+	"gestire": true, "restituire": true, "sparire": true, "stabilire": true, "tradire": true,
+	"obbedire": true, "impedire": true, "garantire": true, "guarire": true, "agire": true,
+}
+
+// italianIrregulars lists the core irregular verbs
+var italianIrregulars = map[string]irregular{
+	"essere": {stem: "sar", forms: map[string][]string{
+		"present":             {"sono", "sei", "è", "siamo", "siete", "sono"},
+		"preterite":           {"fui", "fosti", "fu", "fummo", "foste", "furono"},
+		"imperfect":           {"ero", "eri", "era", "eravamo", "eravate", "erano"},
+		"present_subjunctive": {"sia", "sia", "sia", "siamo", "siate", "siano"},
+	}},
+	"avere": {stem: "avr", forms: map[string][]string{
+		"present":             {"ho", "hai", "ha", "abbiamo", "avete", "hanno"},
+		"preterite":           {"ebbi", "avesti", "ebbe", "avemmo", "aveste", "ebbero"},
+		"present_subjunctive": {"abbia", "abbia", "abbia", "abbiamo", "abbiate", "abbiano"},
+	}},
+	"andare": {stem: "andr", forms: map[string][]string{
+		"present":             {"vado", "vai", "va", "andiamo", "andate", "vanno"},
+		"present_subjunctive": {"vada", "vada", "vada", "andiamo", "andiate", "vadano"},
+	}},
+	"fare": {stem: "far", forms: map[string][]string{
+		"present":             {"faccio", "fai", "fa", "facciamo", "fate", "fanno"},
+		"preterite":           {"feci", "facesti", "fece", "facemmo", "faceste", "fecero"},
+		"imperfect":           {"facevo", "facevi", "faceva", "facevamo", "facevate", "facevano"},
+		"present_subjunctive": {"faccia", "faccia", "faccia", "facciamo", "facciate", "facciano"},
+	}},
+	"dire": {stem: "dir", forms: map[string][]string{
+		"present":             {"dico", "dici", "dice", "diciamo", "dite", "dicono"},
+		"preterite":           {"dissi", "dicesti", "disse", "dicemmo", "diceste", "dissero"},
+		"imperfect":           {"dicevo", "dicevi", "diceva", "dicevamo", "dicevate", "dicevano"},
+		"present_subjunctive": {"dica", "dica", "dica", "diciamo", "diciate", "dicano"},
+	}},
+	"stare": {stem: "star", forms: map[string][]string{
+		"present":             {"sto", "stai", "sta", "stiamo", "state", "stanno"},
+		"preterite":           {"stetti", "stesti", "stette", "stemmo", "steste", "stettero"},
+		"present_subjunctive": {"stia", "stia", "stia", "stiamo", "stiate", "stiano"},
+	}},
+	"dare": {stem: "dar", forms: map[string][]string{
+		"present":             {"do", "dai", "dà", "diamo", "date", "danno"},
+		"preterite":           {"diedi", "desti", "diede", "demmo", "deste", "diedero"},
+		"present_subjunctive": {"dia", "dia", "dia", "diamo", "diate", "diano"},
+	}},
+	"potere": {stem: "potr", forms: map[string][]string{
+		"present":             {"posso", "puoi", "può", "possiamo", "potete", "possono"},
+		"present_subjunctive": {"possa", "possa", "possa", "possiamo", "possiate", "possano"},
+	}},
+	"volere": {stem: "vorr", forms: map[string][]string{
+		"present":             {"voglio", "vuoi", "vuole", "vogliamo", "volete", "vogliono"},
+		"preterite":           {"volli", "volesti", "volle", "volemmo", "voleste", "vollero"},
+		"present_subjunctive": {"voglia", "voglia", "voglia", "vogliamo", "vogliate", "vogliano"},
+	}},
+	"dovere": {stem: "dovr", forms: map[string][]string{
+		"present":             {"devo", "devi", "deve", "dobbiamo", "dovete", "devono"},
+		"present_subjunctive": {"debba", "debba", "debba", "dobbiamo", "dobbiate", "debbano"},
+	}},
+	"sapere": {stem: "sapr", forms: map[string][]string{
+		"present":             {"so", "sai", "sa", "sappiamo", "sapete", "sanno"},
+		"preterite":           {"seppi", "sapesti", "seppe", "sapemmo", "sapeste", "seppero"},
+		"present_subjunctive": {"sappia", "sappia", "sappia", "sappiamo", "sappiate", "sappiano"},
+	}},
+	"venire": {stem: "verr", forms: map[string][]string{
+		"present":             {"vengo", "vieni", "viene", "veniamo", "venite", "vengono"},
+		"preterite":           {"venni", "venisti", "venne", "venimmo", "veniste", "vennero"},
+		"present_subjunctive": {"venga", "venga", "venga", "veniamo", "veniate", "vengano"},
+	}},
+}
+
+// Conjugate builds the conjugation table for an Italian infinitive
+func (italian) Conjugate(verb string) (Table, error) {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+
+	var class, stem string
+	for _, ending := range []string{"are", "ere", "ire"} {
+		if s, ok := trimSuffix(verb, ending); ok {
+			class, stem = ending, s
+			break
+		}
+	}
+
+	irr, isIrregular := italianIrregulars[verb]
+	if class == "" && !isIrregular {
+		return nil, notAVerb("it", verb)
+	}
+
+	endings := italianEndings[class]
+	if italianISC[verb] {
+		endings = italianEndings["isc"]
+	}
+
+	fix := italianSpelling(class)
+	forms := make(map[string][]string)
+	for tense, list := range endings {
+		forms[tense] = attach(stem, list, fix)
+	}
+
+	// The future stem turns -are into -er, e.g. parlare -> parler-
+	future := stem + strings.TrimSuffix(class, "e")
+	if class == "are" {
+		future = fix(stem, "er")
+	}
+	if irr.stem != "" {
+		future = irr.stem
+	}
+	forms["future"] = attach(future, italianFuture, nil)
+	forms["conditional"] = attach(future, italianConditional, nil)
+
+	for tense, list := range irr.forms {
+		forms[tense] = list
+	}
+
+	return table("it", forms), nil
+}
+
+// italianSpelling adjusts -are stems before e and i: -care/-gare keep their
+// hard sound (cercare -> cerchi), -ciare/-giare drop the i (mangiare -> mangerò)
+// and other -iare verbs avoid a doubled i (studiare -> studi)
+func italianSpelling(class string) func(stem, ending string) string {
+	return func(stem, ending string) string {
+		front := strings.HasPrefix(ending, "e") || strings.HasPrefix(ending, "i")
+		if class != "are" || !front {
+			return stem + ending
+		}
+
+		switch {
+		case strings.HasSuffix(stem, "c"), strings.HasSuffix(stem, "g"):
+			return stem + "h" + ending
+		case strings.HasSuffix(stem, "ci"), strings.HasSuffix(stem, "gi"):
+			return strings.TrimSuffix(stem, "i") + ending
+		case strings.HasSuffix(stem, "i") && strings.HasPrefix(ending, "i"):
+			return strings.TrimSuffix(stem, "i") + ending
+		}
+		return stem + ending
+	}
+}
@@ -0,0 +1,167 @@
+package conjugation
+
+import "strings"
+
+// portuguese conjugates regular -ar/-er/-ir verbs and common irregulars
+type portuguese struct{}
+
+// portugueseEndings holds the regular endings per verb class and tense
+var portugueseEndings = map[string]map[string][]string{
+	"ar": {
+		"present":             {"o", "as", "a", "amos", "ais", "am"},
+		"preterite":           {"ei", "aste", "ou", "amos", "astes", "aram"},
+		"imperfect":           {"ava", "avas", "ava", "ávamos", "áveis", "avam"},
+		"present_subjunctive": {"e", "es", "e", "emos", "eis", "em"},
+	},
+	"er": {
+		"present":             {"o", "es", "e", "emos", "eis", "em"},
+		"preterite":           {"i", "este", "eu", "emos", "estes", "eram"},
+		"imperfect":           {"ia", "ias", "ia", "íamos", "íeis", "iam"},
+		"present_subjunctive": {"a", "as", "a", "amos", "ais", "am"},
+	},
+	"ir": {
+		"present":             {"o", "es", "e", "imos", "is", "em"},
+		"preterite":           {"i", "iste", "iu", "imos", "istes", "iram"},
+		"imperfect":           {"ia", "ias", "ia", "íamos", "íeis", "iam"},
+		"present_subjunctive": {"a", "as", "a", "amos", "ais", "am"},
+	},
+}
+
+// Future and conditional endings are added to the whole infinitive
+var (
+	portugueseFuture      = []string{"ei", "ás", "á", "emos", "eis", "ão"}
+	portugueseConditional = []string{"ia", "ias", "ia", "íamos", "íeis", "iam"}
+)
+
+// portugueseIrregulars lists the core irregular verbs
+var portugueseIrregulars = map[string]irregular{
+	"ser": {forms: map[string][]string{
+		"present":             {"sou", "és", "é", "somos", "sois", "são"},
+		"preterite":           {"fui", "foste", "foi", "fomos", "fostes", "foram"},
+		"imperfect":           {"era", "eras", "era", "éramos", "éreis", "eram"},
+		"present_subjunctive": {"seja", "sejas", "seja", "sejamos", "sejais", "sejam"},
+	}},
+	"estar": {forms: map[string][]string{
+		"present":             {"estou", "estás", "está", "estamos", "estais", "estão"},
+		"preterite":           {"estive", "estiveste", "esteve", "estivemos", "estivestes", "estiveram"},
+		"present_subjunctive": {"esteja", "estejas", "esteja", "estejamos", "estejais", "estejam"},
+	}},
+	"ter": {forms: map[string][]string{
+		"present":             {"tenho", "tens", "tem", "temos", "tendes", "têm"},
+		"preterite":           {"tive", "tiveste", "teve", "tivemos", "tivestes", "tiveram"},
+		"imperfect":           {"tinha", "tinhas", "tinha", "tínhamos", "tínheis", "tinham"},
+		"present_subjunctive": {"tenha", "tenhas", "tenha", "tenhamos", "tenhais", "tenham"},
+	}},
+	"haver": {forms: map[string][]string{
+		"present":             {"hei", "hás", "há", "havemos", "haveis", "hão"},
+		"preterite":           {"houve", "houveste", "houve", "houvemos", "houvestes", "houveram"},
+		"present_subjunctive": {"haja", "hajas", "haja", "hajamos", "hajais", "hajam"},
+	}},
+	"ir": {forms: map[string][]string{
+		"present":             {"vou", "vais", "vai", "vamos", "ides", "vão"},
+		"preterite":           {"fui", "foste", "foi", "fomos", "fostes", "foram"},
+		"imperfect":           {"ia", "ias", "ia", "íamos", "íeis", "iam"},
+		"present_subjunctive": {"vá", "vás", "vá", "vamos", "vades", "vão"},
+	}},
+	"fazer": {stem: "far", forms: map[string][]string{
+		"present":             {"faço", "fazes", "faz", "fazemos", "fazeis", "fazem"},
+		"preterite":           {"fiz", "fizeste", "fez", "fizemos", "fizestes", "fizeram"},
+		"present_subjunctive": {"faça", "faças", "faça", "façamos", "façais", "façam"},
+	}},
+	"dizer": {stem: "dir", forms: map[string][]string{
+		"present":             {"digo", "dizes", "diz", "dizemos", "dizeis", "dizem"},
+		"preterite":           {"disse", "disseste", "disse", "dissemos", "dissestes", "disseram"},
+		"present_subjunctive": {"diga", "digas", "diga", "digamos", "digais", "digam"},
+	}},
+	"poder": {forms: map[string][]string{
+		"present":             {"posso", "podes", "pode", "podemos", "podeis", "podem"},
+		"preterite":           {"pude", "pudeste", "pôde", "pudemos", "pudestes", "puderam"},
+		"present_subjunctive": {"possa", "possas", "possa", "possamos", "possais", "possam"},
+	}},
+	"querer": {forms: map[string][]string{
+		"present":             {"quero", "queres", "quer", "queremos", "quereis", "querem"},
+		"preterite":           {"quis", "quiseste", "quis", "quisemos", "quisestes", "quiseram"},
+		"present_subjunctive": {"queira", "queiras", "queira", "queiramos", "queirais", "queiram"},
+	}},
+	"saber": {forms: map[string][]string{
+		"present":             {"sei", "sabes", "sabe", "sabemos", "sabeis", "sabem"},
+		"preterite":           {"soube", "soubeste", "soube", "soubemos", "soubestes", "souberam"},
+		"present_subjunctive": {"saiba", "saibas", "saiba", "saibamos", "saibais", "saibam"},
+	}},
+	"ver": {forms: map[string][]string{
+		"present":             {"vejo", "vês", "vê", "vemos", "vedes", "veem"},
+		"preterite":           {"vi", "viste", "viu", "vimos", "vistes", "viram"},
+		"present_subjunctive": {"veja", "vejas", "veja", "vejamos", "vejais", "vejam"},
+	}},
+	"vir": {forms: map[string][]string{
+		"present":             {"venho", "vens", "vem", "vimos", "vindes", "vêm"},
+		"preterite":           {"vim", "vieste", "veio", "viemos", "viestes", "vieram"},
+		"imperfect":           {"vinha", "vinhas", "vinha", "vínhamos", "vínheis", "vinham"},
+		"present_subjunctive": {"venha", "venhas", "venha", "venhamos", "venhais", "venham"},
+	}},
+	"dar": {forms: map[string][]string{
+		"present":             {"dou", "dás", "dá", "damos", "dais", "dão"},
+		"preterite":           {"dei", "deste", "deu", "demos", "destes", "deram"},
+		"present_subjunctive": {"dê", "dês", "dê", "demos", "deis", "deem"},
+	}},
+}
+
+// Conjugate builds the conjugation table for a Portuguese infinitive
+func (portuguese) Conjugate(verb string) (Table, error) {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+
+	var class, stem string
+	for _, ending := range []string{"ar", "er", "ir"} {
+		if s, ok := trimSuffix(verb, ending); ok {
+			class, stem = ending, s
+			break
+		}
+	}
+
+	irr, isIrregular := portugueseIrregulars[verb]
+	if class == "" && !isIrregular {
+		return nil, notAVerb("pt", verb)
+	}
+
+	forms := make(map[string][]string)
+	for tense, endings := range portugueseEndings[class] {
+		forms[tense] = attach(stem, endings, portugueseSpelling(class))
+	}
+
+	future := verb
+	if irr.stem != "" {
+		future = irr.stem
+	}
+	forms["future"] = attach(future, portugueseFuture, nil)
+	forms["conditional"] = attach(future, portugueseConditional, nil)
+
+	for tense, list := range irr.forms {
+		forms[tense] = list
+	}
+
+	return table("pt", forms), nil
+}
+
+// portugueseSpelling keeps the stem's sound before endings that would change it,
+// e.g. ficar -> fiquei, chegar -> chegue, conhecer -> conheço, proteger -> protejo
+func portugueseSpelling(class string) func(stem, ending string) string {
+	return func(stem, ending string) string {
+		front := strings.HasPrefix(ending, "e") || strings.HasPrefix(ending, "i")
+		back := strings.HasPrefix(ending, "a") || strings.HasPrefix(ending, "o")
+
+		switch {
+		case class == "ar" && front && strings.HasSuffix(stem, "ç"):
+			stem = strings.TrimSuffix(stem, "ç") + "c"
+		case class == "ar" && front && strings.HasSuffix(stem, "c"):
+			stem = strings.TrimSuffix(stem, "c") + "qu"
+		case class == "ar" && front && strings.HasSuffix(stem, "g"):
+			stem = strings.TrimSuffix(stem, "g") + "gu"
+		case class != "ar" && back && strings.HasSuffix(stem, "c"):
+			stem = strings.TrimSuffix(stem, "c") + "ç"
+		case class != "ar" && back && strings.HasSuffix(stem, "g"):
+			stem = strings.TrimSuffix(stem, "g") + "j"
+		}
+
+		return stem + ending
+	}
+}
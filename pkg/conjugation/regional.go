@@ -0,0 +1,242 @@
+package conjugation
+
+import (
+	"slices"
+	"strings"
+
+	"tr/pkg/lang"
+)
+
+// voseoPresent lists verbs whose vos present form can't be derived from vosotros
+var voseoPresent = map[string]string{
+	"sois":   "sos", // ser
+	"vais":   "vas", // ir
+	"habéis": "has", // haber
+	"dais":   "das", // dar
+	"veis":   "ves", // ver
+}
+
+// tuImperatives lists irregular affirmative tú imperatives
+var tuImperatives = map[string]string{
+	"decir": "di", "hacer": "haz", "ir": "ve", "poner": "pon",
+	"salir": "sal", "ser": "sé", "tener": "ten", "venir": "ven",
+}
+
+// vosImperatives lists vos imperatives that don't follow the infinitive
+var vosImperatives = map[string]string{
+	"ir": "andá",
+}
+
+// subjunctiveImperatives lists the usted, nosotros and ustedes imperatives
+// of verbs whose subjunctive isn't built from the yo form
+var subjunctiveImperatives = map[string][3]string{
+	"ser":   {"sea", "seamos", "sean"},
+	"ir":    {"vaya", "vamos", "vayan"},
+	"estar": {"esté", "estemos", "estén"},
+	"dar":   {"dé", "demos", "den"},
+	"saber": {"sepa", "sepamos", "sepan"},
+	"haber": {"haya", "hayamos", "hayan"},
+}
+
+// ForRegion adapts Spanish conjugations to a region from lang.Regions: it
+// adds the imperative, vos forms where voseo is used, and copies the ellos
+// forms to the region's "ellos/ustedes" person when it has one
+func ForRegion(verb string, conjugations Table, region string) Table {
+	result := AddImperative(verb, conjugations)
+
+	r, ok := lang.LookupRegion(region)
+	if !ok {
+		return result
+	}
+	if r.Voseo {
+		result = AddVoseo(verb, result)
+	}
+	if slices.Contains(r.Persons, "ellos/ustedes") {
+		result = cloneTable(result)
+		for _, forms := range result {
+			if form, ok := forms["ellos"]; ok {
+				forms["ellos/ustedes"] = form
+			}
+		}
+	}
+	return result
+}
+
+// AddImperative returns a copy of Spanish conjugations with the affirmative
+// imperative, derived from the present (and present subjunctive, when it is
+// available). The él/ella and ellos rows hold the usted and ustedes forms.
+// Conjugations that already have an imperative are returned unchanged.
+func AddImperative(verb string, conjugations Table) Table {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+	present := conjugations["present"]
+	if present == nil || conjugations["imperative"] != nil {
+		return conjugations
+	}
+
+	stem, class := spanishClass(verb)
+	if class == "" {
+		return conjugations // Reflexive or not an infinitive
+	}
+
+	imperative := map[string]string{
+		"tú":       present["él/ella"],
+		"vosotros": strings.TrimSuffix(verb, "r") + "d", // hablad, comed, vivid
+	}
+	if form, ok := tuImperatives[verb]; ok {
+		imperative["tú"] = form
+	}
+
+	usted, nosotros, ustedes := subjunctiveForms(stem, class, present["yo"])
+	if subjunctive := conjugations["present_subjunctive"]; subjunctive != nil {
+		usted, nosotros, ustedes = subjunctive["él/ella"], subjunctive["nosotros"], subjunctive["ellos"]
+	}
+	if forms, ok := subjunctiveImperatives[verb]; ok {
+		usted, nosotros, ustedes = forms[0], forms[1], forms[2]
+	}
+	for person, form := range map[string]string{"él/ella": usted, "nosotros": nosotros, "ellos": ustedes} {
+		if form != "" {
+			imperative[person] = form
+		}
+	}
+
+	result := cloneTable(conjugations)
+	result["imperative"] = imperative
+	return result
+}
+
+// AddVoseo returns a copy of Spanish conjugations with a "vos" person.
+// The present is built from the vosotros form by dropping the i of the
+// ending (habláis -> hablás, coméis -> comés, vivís -> vivís) and the
+// imperative stresses the infinitive's last vowel (hablá, comé, viví);
+// other tenses use the tú forms, as is usual in Rioplatense Spanish.
+func AddVoseo(verb string, conjugations Table) Table {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+	result := cloneTable(conjugations)
+
+	for tense, forms := range result {
+		switch {
+		case tense == "present" && forms["vosotros"] != "":
+			forms["vos"] = voseoForm(forms["vosotros"])
+		case tense == "imperative":
+			forms["vos"] = vosImperative(verb)
+		case forms["tú"] != "":
+			forms["vos"] = forms["tú"]
+		}
+	}
+	return result
+}
+
+// voseoForm derives the vos present from the vosotros present
+func voseoForm(vosotros string) string {
+	if form, ok := voseoPresent[vosotros]; ok {
+		return form
+	}
+
+	for _, ending := range []string{"áis", "éis"} {
+		if stem, ok := strings.CutSuffix(vosotros, ending); ok {
+			return stem + strings.TrimSuffix(ending, "is") + "s"
+		}
+	}
+	return vosotros // -ir verbs: vivís is the same for both
+}
+
+// vosImperative drops the r of the infinitive and accents the vowel before it
+func vosImperative(verb string) string {
+	if form, ok := vosImperatives[verb]; ok {
+		return form
+	}
+
+	runes := []rune(strings.TrimSuffix(verb, "r"))
+	if len(runes) == 0 {
+		return verb
+	}
+	if accented, ok := map[rune]rune{'a': 'á', 'e': 'é', 'i': 'í'}[runes[len(runes)-1]]; ok {
+		runes[len(runes)-1] = accented
+	}
+	return string(runes)
+}
+
+// spanishClass splits an infinitive into its stem and class (ar, er or ir)
+func spanishClass(verb string) (stem, class string) {
+	if verb == "ir" {
+		return "", "ir" // The only infinitive without a stem
+	}
+	for _, ending := range []string{"ar", "er", "ir", "ír"} {
+		if s, ok := trimSuffix(verb, ending); ok {
+			return s, strings.ReplaceAll(ending, "í", "i")
+		}
+	}
+	return "", ""
+}
+
+// subjunctiveForms builds the usted, nosotros and ustedes present
+// subjunctive from the yo present (tengo -> tenga, pienso -> piense). The
+// nosotros form keeps the infinitive's stem vowel (pensemos), which -ir
+// verbs raise (durmamos, sintamos).
+func subjunctiveForms(stem, class, yo string) (usted, nosotros, ustedes string) {
+	yoStem, ok := strings.CutSuffix(yo, "o")
+	if !ok {
+		return "", "", "" // soy, doy, voy and other irregular yo forms
+	}
+
+	vowel := "a"
+	if class == "ar" {
+		vowel = "e"
+	}
+
+	nosStem := yoStem
+	if i, changed := diphthongIndex(yoStem, stem); changed {
+		nosStem = stem
+		if class == "ir" {
+			raised := map[byte]string{'e': "i", 'o': "u"}
+			if r, ok := raised[stem[i]]; ok {
+				nosStem = stem[:i] + r + stem[i+1:]
+			}
+		}
+	}
+
+	if class == "ar" {
+		yoStem, nosStem = hardenStem(yoStem), hardenStem(nosStem)
+	}
+	return yoStem + vowel, nosStem + vowel + "mos", yoStem + vowel + "n"
+}
+
+// diphthongIndex reports whether the yo stem differs from the infinitive
+// stem only by a stem-changing diphthong (piens/pens, duerm/dorm, jueg/jug)
+// and returns the position of the changed vowel in the infinitive stem
+func diphthongIndex(yoStem, stem string) (int, bool) {
+	for _, change := range [][2]string{{"ie", "e"}, {"ie", "i"}, {"ue", "o"}, {"ue", "u"}} {
+		i := strings.LastIndex(yoStem, change[0])
+		if i >= 0 && yoStem[:i]+change[1]+yoStem[i+2:] == stem {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// hardenStem keeps the sound of an -ar stem before e (busc -> busqu,
+// pag -> pagu, empiez -> empiec)
+func hardenStem(stem string) string {
+	switch {
+	case strings.HasSuffix(stem, "c"):
+		return strings.TrimSuffix(stem, "c") + "qu"
+	case strings.HasSuffix(stem, "g"):
+		return stem + "u"
+	case strings.HasSuffix(stem, "z"):
+		return strings.TrimSuffix(stem, "z") + "c"
+	}
+	return stem
+}
+
+// cloneTable copies a conjugation table so callers' maps aren't modified
+func cloneTable(conjugations Table) Table {
+	result := make(Table, len(conjugations)+1)
+	for tense, forms := range conjugations {
+		copied := make(map[string]string, len(forms)+1)
+		for person, form := range forms {
+			copied[person] = form
+		}
+		result[tense] = copied
+	}
+	return result
+}
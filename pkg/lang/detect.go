@@ -0,0 +1,150 @@
+package lang
+
+import (
+	"strings"
+	"unicode"
+
+	"tr/pkg/lexicon"
+)
+
+// profile holds the cues used to recognise a language
+type profile struct {
+	chars    string          // characters that only (or mostly) occur in this language
+	words    map[string]bool // very common short words
+	suffixes []string        // typical word endings
+}
+
+// profiles maps language codes to their detection cues
+var profiles = map[string]profile{
+	"es": {
+		chars: "ñáéíóúü¿¡",
+		words: wordSet("el la los las un una unos unas de del al y o que en es son por para con sin no sí yo tú él ella nosotros ellos mi tu su muy pero como más hola gracias buenos buenas días noches qué cómo dónde cuándo quién este esta eso hay estoy está soy eres"),
+		suffixes: []string{
+			"ción", "sión", "mente", "dad", "ando", "iendo", "ado", "ido", "ar", "er", "ir", "os", "as", "o", "a",
+		},
+	},
+	"en": {
+		chars: "kw",
+		words: wordSet("the a an of and or to in on at is are was were be been it this that these those i you he she we they my your his her our their not no yes with without for from by what how where when who hello thanks good morning night please do does did have has will would can could"),
+		suffixes: []string{
+			"ing", "tion", "ly", "ed", "er", "ness", "ship", "ful", "less", "ous", "th", "sh", "ght", "t", "k", "p", "g", "b", "m", "f",
+		},
+	},
+	"pt": {
+		chars: "ãõçâêôà",
+		words: wordSet("o a os as um uma de do da dos das em no na e que não sim eu tu ele ela nós eles com para por muito obrigado obrigada olá bom boa dia noite você é são está estou"),
+		suffixes: []string{
+			"ção", "ções", "mente", "dade", "ando", "endo", "indo", "ado", "ido", "ar", "er", "ir", "ão", "os", "as", "o", "a",
+		},
+	},
+	"fr": {
+		chars: "çàâèêëîïôùûœ",
+		words: wordSet("le la les un une des de du et ou que qui est sont je tu il elle nous vous ils elles ne pas oui non avec pour dans sur très bonjour merci bonsoir au aux ce cette c'est je suis"),
+		suffixes: []string{
+			"tion", "ment", "eux", "euse", "ais", "ait", "ez", "er", "ir", "re", "é", "ée", "que", "eau", "x",
+		},
+	},
+	"it": {
+		chars: "àèéìòù",
+		words: wordSet("il lo la gli le un uno una di del della e che non sì io tu lui lei noi voi loro con per molto ciao grazie buongiorno buonasera sono è sei siamo questo questa"),
+		suffixes: []string{
+			"zione", "mente", "tà", "ando", "endo", "ato", "ito", "are", "ere", "ire", "i", "e", "o", "a",
+		},
+	},
+	"de": {
+		chars: "äöüß",
+		words: wordSet("der die das ein eine und oder ist sind ich du er sie wir ihr nicht ja nein mit für von zu auf sehr hallo danke guten morgen abend"),
+		suffixes: []string{
+			"ung", "heit", "keit", "lich", "isch", "chen", "en", "ern", "st", "sch", "z",
+		},
+	},
+}
+
+// wordSet builds a lookup set from a space separated word list
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// Detect guesses which of the candidate languages text is written in.
+// Ties between Spanish and another language go to Spanish if and only if
+// the bundled lexicon knows every word, so single words without cues such
+// as "house" aren't taken for Spanish while "comer" is. Other ties, and
+// texts without any cues, resolve to the first candidate.
+func Detect(text string, candidates ...string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	best := candidates[0]
+	bestScore := score(text, best)
+	for _, code := range candidates[1:] {
+		score := score(text, code)
+		switch {
+		case score > bestScore:
+			best = code
+			bestScore = score
+		case score == bestScore && (best == "es" || code == "es"):
+			if (code == "es") == knownSpanish(text) {
+				best = code
+			}
+		}
+	}
+
+	return best
+}
+
+// knownSpanish reports whether every word of text is in the Spanish
+// lexicon, allowing for missing accents
+func knownSpanish(text string) bool {
+	words := splitWords(strings.ToLower(text))
+	for _, word := range words {
+		if !lexicon.Known("es", word) && len(lexicon.AccentCandidates("es", word)) == 0 {
+			return false
+		}
+	}
+	return len(words) > 0
+}
+
+// splitWords splits text into words, keeping apostrophes inside them
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+// score rates how strongly text matches the given language
+func score(text, code string) int {
+	p, ok := profiles[code]
+	if !ok {
+		return 0
+	}
+
+	text = strings.ToLower(text)
+	total := 0
+
+	// Language specific characters are the strongest signal
+	for _, char := range text {
+		if strings.ContainsRune(p.chars, char) {
+			total += 3
+		}
+	}
+
+	for _, word := range splitWords(text) {
+		if p.words[word] {
+			total += 2
+			continue
+		}
+		for _, suffix := range p.suffixes {
+			if len(word) > len(suffix)+1 && strings.HasSuffix(word, suffix) {
+				total++
+				break
+			}
+		}
+	}
+
+	return total
+}
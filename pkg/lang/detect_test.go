@@ -0,0 +1,63 @@
+package lang
+
+import "testing"
+
+func TestDetectSpanishEnglish(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		// Single English words without cues aren't taken for Spanish
+		{"house", "en"},
+		{"computer", "en"},
+		{"table", "en"},
+		{"run", "en"},
+		{"apple", "en"},
+		{"teacher", "en"},
+		{"mother", "en"},
+		{"walking", "en"},
+		{"good morning", "en"},
+
+		// Spanish words the lexicon knows win ties
+		{"comer", "es"},
+		{"beber", "es"},
+		{"mujer", "es"},
+		{"casa", "es"},
+		{"perro", "es"},
+		{"hablar", "es"},
+		{"manana", "es"}, // Known once accents are added
+		{"mañana", "es"},
+		{"hola", "es"},
+		{"buenos días", "es"},
+		{"¿dónde está el baño?", "es"},
+	}
+
+	for _, tt := range tests {
+		for _, candidates := range [][]string{{"es", "en"}, {"en", "es"}} {
+			if got := Detect(tt.text, candidates...); got != tt.want {
+				t.Errorf("Detect(%q, %v) = %s, want %s", tt.text, candidates, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestDetectOtherLanguages(t *testing.T) {
+	tests := []struct {
+		text       string
+		candidates []string
+		want       string
+	}{
+		{"bonjour, je suis très content", []string{"es", "fr"}, "fr"},
+		{"obrigado, não sei", []string{"es", "pt"}, "pt"},
+		{"grazie mille, ciao", []string{"es", "it"}, "it"},
+		{"guten Morgen, danke", []string{"en", "de"}, "de"},
+		{"", []string{"fr", "it"}, "fr"}, // No cues: first candidate
+		{"hola", nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(tt.text, tt.candidates...); got != tt.want {
+			t.Errorf("Detect(%q, %v) = %q, want %q", tt.text, tt.candidates, got, tt.want)
+		}
+	}
+}
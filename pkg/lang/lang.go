@@ -0,0 +1,192 @@
+// Package lang describes the supported languages, their persons and tenses,
+// regional varieties of Spanish, and detects the language of a text.
+package lang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language describes a language the translator can work with
+type Language struct {
+	Code         string   // ISO 639-1 code used by the translation service
+	Name         string   // English display name
+	Conjugations bool     // Whether verb conjugations are supported
+	Persons      []string // Grammatical persons in display order
+	Tenses       []string // Tenses that can be conjugated, in display order
+}
+
+// simpleTenses are the tenses produced by the rule-based conjugators
+var simpleTenses = []string{
+	"present",
+	"preterite",
+	"imperfect",
+	"future",
+	"conditional",
+	"present_subjunctive",
+}
+
+// registry lists all known languages in display order
+var registry = []Language{
+	{
+		Code:         "es",
+		Name:         "Spanish",
+		Conjugations: true,
+		Persons:      []string{"yo", "tú", "él/ella", "nosotros", "vosotros", "ellos"},
+		Tenses: []string{
+			"present",
+			"preterite",
+			"imperfect",
+			"future",
+			"conditional",
+			"present_subjunctive",
+			"imperfect_subjunctive",
+			"present_perfect",
+			"pluperfect",
+			"future_perfect",
+			"conditional_perfect",
+			"present_perfect_subjunctive",
+			"imperative",
+		},
+	},
+	{Code: "en", Name: "English"},
+	{
+		Code:         "pt",
+		Name:         "Portuguese",
+		Conjugations: true,
+		Persons:      []string{"eu", "tu", "ele/ela", "nós", "vós", "eles"},
+		Tenses:       simpleTenses,
+	},
+	{
+		Code:         "fr",
+		Name:         "French",
+		Conjugations: true,
+		Persons:      []string{"je", "tu", "il/elle", "nous", "vous", "ils"},
+		Tenses:       simpleTenses,
+	},
+	{
+		Code:         "it",
+		Name:         "Italian",
+		Conjugations: true,
+		Persons:      []string{"io", "tu", "lui/lei", "noi", "voi", "loro"},
+		Tenses:       simpleTenses,
+	},
+	{Code: "de", Name: "German"},
+	{Code: "ca", Name: "Catalan"},
+	{Code: "nl", Name: "Dutch"},
+}
+
+// All returns every registered language
+func All() []Language {
+	return append([]Language(nil), registry...)
+}
+
+// Lookup finds a registered language by its code
+func Lookup(code string) (Language, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, l := range registry {
+		if l.Code == code {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
+
+// Name returns the display name for a language code
+func Name(code string) string {
+	if l, ok := Lookup(code); ok {
+		return l.Name
+	}
+	return strings.ToUpper(code)
+}
+
+// SupportsConjugation reports whether verb conjugations are available for a language
+func SupportsConjugation(code string) bool {
+	l, ok := Lookup(code)
+	return ok && l.Conjugations
+}
+
+// Persons returns the grammatical persons for a language, or nil if it has no conjugations
+func Persons(code string) []string {
+	l, _ := Lookup(code)
+	return append([]string(nil), l.Persons...)
+}
+
+// Tenses returns the conjugatable tenses for a language, or nil if it has no conjugations
+func Tenses(code string) []string {
+	l, _ := Lookup(code)
+	return append([]string(nil), l.Tenses...)
+}
+
+// ParseDirection splits a direction such as "es2en" or "fr2es" into its
+// source and target language codes
+func ParseDirection(direction string) (from, to string, err error) {
+	direction = strings.ToLower(strings.TrimSpace(direction))
+
+	parts := strings.Split(direction, "2")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid direction %q, expected <from>2<to> such as es2en", direction)
+	}
+
+	return ParsePair(parts[0], parts[1])
+}
+
+// ParsePair validates a source and target language code pair
+func ParsePair(from, to string) (string, string, error) {
+	from = strings.ToLower(strings.TrimSpace(from))
+	to = strings.ToLower(strings.TrimSpace(to))
+
+	if _, ok := Lookup(from); !ok {
+		return "", "", fmt.Errorf("unsupported language %q (supported: %s)", from, strings.Join(Codes(), ", "))
+	}
+	if _, ok := Lookup(to); !ok {
+		return "", "", fmt.Errorf("unsupported language %q (supported: %s)", to, strings.Join(Codes(), ", "))
+	}
+	if from == to {
+		return "", "", fmt.Errorf("source and target language are both %q", from)
+	}
+
+	return from, to, nil
+}
+
+// Direction joins a source and target language code into a direction string
+func Direction(from, to string) string {
+	return from + "2" + to
+}
+
+// Codes returns the codes of all registered languages
+func Codes() []string {
+	codes := make([]string, 0, len(registry))
+	for _, l := range registry {
+		codes = append(codes, l.Code)
+	}
+	return codes
+}
+
+// Counterpart returns the default language to pair with code: English for
+// everything except English itself, which pairs with Spanish
+func Counterpart(code string) string {
+	if code == "en" {
+		return "es"
+	}
+	return "en"
+}
+
+// Moods lists the grammatical moods tenses are grouped into
+var Moods = []string{"indicative", "subjunctive", "conditional", "imperative"}
+
+// Mood returns the grammatical mood of a tense: subjunctive for the
+// *_subjunctive tenses, conditional for the conditionals, imperative for the
+// imperative and indicative otherwise
+func Mood(tense string) string {
+	switch {
+	case tense == "imperative":
+		return "imperative"
+	case strings.HasSuffix(tense, "subjunctive"):
+		return "subjunctive"
+	case strings.HasPrefix(tense, "conditional"):
+		return "conditional"
+	default:
+		return "indicative"
+	}
+}
@@ -0,0 +1,88 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Region describes a regional variety of Spanish
+type Region struct {
+	Code    string   // Name used in the config, e.g. "mexico"
+	Name    string   // Display name
+	Locale  string   // Locale passed to translation services, e.g. "es-MX"
+	Persons []string // Persons shown in conjugation tables, in display order
+	Voseo   bool     // Whether vos replaces tú
+}
+
+// DefaultRegion is the region used when none is configured
+const DefaultRegion = "spain"
+
+// regions lists the supported Spanish varieties
+var regions = []Region{
+	{
+		Code:    "spain",
+		Name:    "Spain",
+		Locale:  "es-ES",
+		Persons: []string{"yo", "tú", "él/ella", "nosotros", "vosotros", "ellos"},
+	},
+	{
+		Code:    "mexico",
+		Name:    "Mexico and most of Latin America",
+		Locale:  "es-MX",
+		Persons: []string{"yo", "tú", "él/ella", "nosotros", "ellos/ustedes"},
+	},
+	{
+		Code:    "rioplatense",
+		Name:    "Rioplatense (Argentina, Uruguay)",
+		Locale:  "es-AR",
+		Persons: []string{"yo", "vos", "él/ella", "nosotros", "ellos/ustedes"},
+		Voseo:   true,
+	},
+}
+
+// Regions returns every supported region
+func Regions() []Region {
+	return append([]Region(nil), regions...)
+}
+
+// LookupRegion finds a region by its code
+func LookupRegion(code string) (Region, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, r := range regions {
+		if r.Code == code {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// ParseRegion validates a region code
+func ParseRegion(code string) (string, error) {
+	r, ok := LookupRegion(code)
+	if !ok {
+		codes := make([]string, 0, len(regions))
+		for _, r := range regions {
+			codes = append(codes, r.Code)
+		}
+		return "", fmt.Errorf("unsupported region %q (supported: %s)", code, strings.Join(codes, ", "))
+	}
+	return r.Code, nil
+}
+
+// RegionPersons returns the persons to display for a language in a region.
+// Regions only apply to Spanish; other languages use their own persons.
+func RegionPersons(code, region string) []string {
+	if r, ok := LookupRegion(region); ok && code == "es" {
+		return append([]string(nil), r.Persons...)
+	}
+	return Persons(code)
+}
+
+// Locale returns the locale to request from translation services for a
+// language in a region, e.g. "es-MX", or the plain code outside Spanish
+func Locale(code, region string) string {
+	if r, ok := LookupRegion(region); ok && code == "es" {
+		return r.Locale
+	}
+	return code
+}
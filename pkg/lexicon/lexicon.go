@@ -0,0 +1,191 @@
+// Package lexicon holds bundled Spanish word lists for accent correction,
+// input shortcuts and spelling suggestions.
+package lexicon
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed es_words.txt
+var spanishWords string
+
+//go:embed es_verbs.txt
+var spanishVerbs string
+
+var (
+	loadOnce sync.Once
+	words    []string            // all words, most frequent first
+	verbs    []string            // verb infinitives, most frequent first
+	known    map[string]bool     // exact spellings
+	byFolded map[string][]string // accent-folded spelling -> spellings
+)
+
+// load parses the embedded word lists
+func load() {
+	loadOnce.Do(func() {
+		verbs = parseList(spanishVerbs)
+		words = append(parseList(spanishWords), verbs...)
+
+		known = make(map[string]bool)
+		byFolded = make(map[string][]string)
+		for _, w := range words {
+			if known[w] {
+				continue
+			}
+			known[w] = true
+			folded := Fold(w)
+			byFolded[folded] = append(byFolded[folded], w)
+		}
+	})
+}
+
+// parseList splits an embedded list into words, skipping blanks and # comments
+func parseList(data string) []string {
+	var list []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	return list
+}
+
+// Words returns the bundled words for a language, most frequent first.
+// Only Spanish has a bundled lexicon.
+func Words(code string) []string {
+	if code != "es" {
+		return nil
+	}
+	load()
+	return append([]string(nil), words...)
+}
+
+// Verbs returns the bundled verb infinitives for a language, most frequent first
+func Verbs(code string) []string {
+	if code != "es" {
+		return nil
+	}
+	load()
+	return append([]string(nil), verbs...)
+}
+
+// Known reports whether word is spelled exactly as in the lexicon
+func Known(code, word string) bool {
+	if code != "es" {
+		return false
+	}
+	load()
+	return known[strings.ToLower(word)]
+}
+
+// foldReplacer strips accents and diacritics from lowercase text
+var foldReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+	"â", "a", "ê", "e", "î", "i", "ô", "o", "û", "u",
+	"ä", "a", "ë", "e", "ï", "i", "ö", "o", "ü", "u",
+	"ã", "a", "õ", "o", "ñ", "n", "ç", "c", "ý", "y", "ÿ", "y",
+)
+
+// Fold lowercases text and removes accents, so "Mañana" and "manana" compare equal
+func Fold(text string) string {
+	return foldReplacer.Replace(strings.ToLower(text))
+}
+
+// AccentCandidates returns accented spellings of word from the lexicon, most
+// frequent first. Words already spelled correctly have no candidates.
+func AccentCandidates(code, word string) []string {
+	if code != "es" {
+		return nil
+	}
+	load()
+
+	lower := strings.ToLower(word)
+	if known[lower] {
+		return nil
+	}
+
+	var candidates []string
+	for _, w := range byFolded[Fold(lower)] {
+		if w != lower {
+			candidates = append(candidates, w)
+		}
+	}
+	return candidates
+}
+
+// CorrectAccents replaces each word of text that is unknown to the lexicon
+// with its most frequent accented spelling. It reports whether anything changed.
+func CorrectAccents(code, text string) (string, bool) {
+	changed := false
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	corrected := text
+	for _, field := range fields {
+		candidates := AccentCandidates(code, field)
+		if len(candidates) == 0 {
+			continue
+		}
+		corrected = replaceWord(corrected, field, matchCase(field, candidates[0]))
+		changed = true
+	}
+
+	return corrected, changed
+}
+
+// replaceWord replaces the first whole-word occurrence of old in text
+func replaceWord(text, old, replacement string) string {
+	runes := []rune(text)
+	oldRunes := []rune(old)
+	for i := 0; i+len(oldRunes) <= len(runes); i++ {
+		if string(runes[i:i+len(oldRunes)]) != old {
+			continue
+		}
+		before := i == 0 || !unicode.IsLetter(runes[i-1])
+		after := i+len(oldRunes) == len(runes) || !unicode.IsLetter(runes[i+len(oldRunes)])
+		if before && after {
+			return string(runes[:i]) + replacement + string(runes[i+len(oldRunes):])
+		}
+	}
+	return text
+}
+
+// matchCase capitalises word like original ("Manana" -> "Mañana")
+func matchCase(original, word string) string {
+	orig := []rune(original)
+	if len(orig) == 0 || !unicode.IsUpper(orig[0]) {
+		return word
+	}
+	if strings.ToUpper(original) == original && len(orig) > 1 {
+		return strings.ToUpper(word)
+	}
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// shortcuts maps ASCII sequences to accented letters for keyboards without a
+// Spanish layout: a vowel followed by ' gets an acute accent, n~ becomes ñ
+// and u" becomes ü
+var shortcuts = strings.NewReplacer(
+	"a'", "á", "e'", "é", "i'", "í", "o'", "ó", "u'", "ú",
+	"A'", "Á", "E'", "É", "I'", "Í", "O'", "Ó", "U'", "Ú",
+	"n~", "ñ", "N~", "Ñ", `u"`, "ü", `U"`, "Ü",
+)
+
+// ExpandShortcuts replaces accent shortcuts such as n~ and a' with the
+// accented letters. Only Spanish text is expanded, since apostrophes are
+// part of French and Italian words like "qu'il" and "po'".
+func ExpandShortcuts(code, text string) string {
+	if code != "es" {
+		return text
+	}
+	return shortcuts.Replace(text)
+}
@@ -0,0 +1,25 @@
+package lexicon
+
+import "testing"
+
+func TestExpandShortcuts(t *testing.T) {
+	tests := []struct {
+		code, text, want string
+	}{
+		{"es", "man~ana", "mañana"},
+		{"es", "a'rbol", "árbol"},
+		{"es", "pingu\"ino", "pingüino"},
+		{"es", "N~ANDU'", "ÑANDÚ"},
+		{"fr", "qu'il", "qu'il"},
+		{"fr", "jusqu'à demain", "jusqu'à demain"},
+		{"it", "un po'", "un po'"},
+		{"pt", "n~ao", "n~ao"},
+		{"en", "don't", "don't"},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandShortcuts(tt.code, tt.text); got != tt.want {
+			t.Errorf("ExpandShortcuts(%q, %q) = %q, want %q", tt.code, tt.text, got, tt.want)
+		}
+	}
+}
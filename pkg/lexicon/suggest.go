@@ -0,0 +1,160 @@
+package lexicon
+
+import (
+	"sort"
+	"strings"
+)
+
+// Distance returns the number of single-character insertions, deletions,
+// substitutions and adjacent transpositions needed to turn a into b
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	// Three rolling rows are enough for transpositions
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(t)]
+}
+
+// spanishSounds rewrites spellings that sound alike in Spanish to one form.
+// Longer sequences come first so "que" is handled before "qu" and "c".
+var spanishSounds = strings.NewReplacer(
+	"ch", "C", "ll", "y", "rr", "r",
+	"que", "ke", "qui", "ki", "gue", "ge", "gui", "gi",
+	"ce", "se", "ci", "si", "ge", "je", "gi", "ji",
+	"qu", "k", "c", "k", "z", "s", "v", "b", "w", "b", "h", "",
+)
+
+// PhoneticKey returns a key that is equal for words that sound alike, so
+// "haber", "aver" and "a ver" share one. Only Spanish has phonetic rules;
+// other languages fall back to the accent-folded word.
+func PhoneticKey(code, word string) string {
+	folded := Fold(word)
+	if code != "es" {
+		return folded
+	}
+
+	key := spanishSounds.Replace(strings.ReplaceAll(folded, " ", ""))
+
+	// Collapse doubled letters left over, e.g. "leer" -> "ler"
+	var b strings.Builder
+	var last rune
+	for _, r := range key {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+// Suggest returns up to n words from the lexicon that are close to word in
+// spelling or sound, best first
+func Suggest(code, word string, n int) []string {
+	if Known(code, word) {
+		return nil
+	}
+	return rank(code, word, Words(code), n)
+}
+
+// SuggestVerbs returns up to n verb infinitives close to word, best first
+func SuggestVerbs(code, word string, n int) []string {
+	verb := strings.ToLower(strings.TrimSpace(word))
+	for _, v := range Verbs(code) {
+		if v == verb {
+			return nil
+		}
+	}
+	return rank(code, word, Verbs(code), n)
+}
+
+// rank scores candidates against word. Each edit costs four points, a word
+// that sounds the same costs at most two, and sharing the consonants takes
+// one off, since dropped vowels are the most common typo ("hablr"). Ties keep
+// the lexicon's frequency order.
+func rank(code, word string, candidates []string, n int) []string {
+	folded := Fold(strings.TrimSpace(word))
+	if folded == "" || n <= 0 {
+		return nil
+	}
+
+	// Allow more typos in longer words
+	maxDistance := 1
+	if len([]rune(folded)) > 4 {
+		maxDistance = 2
+	}
+
+	type scored struct {
+		word  string
+		score int
+	}
+
+	key := PhoneticKey(code, folded)
+	skeleton := consonants(folded)
+	seen := make(map[string]bool)
+	var matches []scored
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate == strings.ToLower(word) {
+			continue
+		}
+		seen[candidate] = true
+
+		distance := Distance(folded, Fold(candidate))
+		sounds := PhoneticKey(code, candidate) == key
+		if distance > maxDistance && !sounds {
+			continue
+		}
+
+		score := distance * 4
+		if sounds {
+			score = min(score, 2)
+		}
+		if consonants(Fold(candidate)) == skeleton {
+			score--
+		}
+		matches = append(matches, scored{candidate, score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+
+	var suggestions []string
+	for _, m := range matches {
+		if len(suggestions) == n {
+			break
+		}
+		suggestions = append(suggestions, m.word)
+	}
+	return suggestions
+}
+
+// consonants returns word without its vowels
+func consonants(word string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("aeiou", r) {
+			return -1
+		}
+		return r
+	}, word)
+}
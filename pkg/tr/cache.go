@@ -0,0 +1,189 @@
+package tr
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+)
+
+// Cache management methods. Caching is optional, so failures to read or
+// write the cache files are only logged.
+
+// load reads the cache files the first time a cache is used, so creating
+// a translator doesn't touch the disk
+func (t *translator) load() {
+	t.loadOnce.Do(func() {
+		if t.cacheFile == "" {
+			return // Caches are kept in memory only
+		}
+
+		var cache map[string]conjugation.Table
+		if t.readCacheFile(t.cacheFile, &cache) && cache != nil {
+			t.cacheMux.Lock()
+			t.cache = cache
+			t.cacheMux.Unlock()
+		}
+
+		var translations map[string]*TranslationResult
+		if t.readCacheFile(t.translationsFile, &translations) && translations != nil {
+			t.cacheMux.Lock()
+			t.translated = translations
+			t.cacheMux.Unlock()
+		}
+	})
+}
+
+// readCacheFile decodes a cache file into v, reporting whether it could
+func (t *translator) readCacheFile(path string, v any) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false // Cache file doesn't exist or can't be read
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.logger.Printf("ignoring cache %s: %v", path, err)
+		return false
+	}
+	return true
+}
+
+// saveCache saves current cache to file
+func (t *translator) saveCache() {
+	t.writeCacheFile(t.cacheFile, t.cache)
+}
+
+// writeCacheFile writes a cache as JSON, one write at a time
+func (t *translator) writeCacheFile(path string, cache any) {
+	if path == "" {
+		return // Caches are kept in memory only
+	}
+
+	t.saveMux.Lock()
+	defer t.saveMux.Unlock()
+
+	t.cacheMux.RLock()
+	data, err := json.MarshalIndent(cache, "", "  ")
+	t.cacheMux.RUnlock()
+	if err != nil {
+		t.logger.Printf("encoding cache %s: %v", path, err)
+		return
+	}
+
+	// Create cache directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.logger.Printf("saving cache: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.logger.Printf("saving cache: %v", err)
+	}
+}
+
+// saveAsync writes a cache in the background, tracked by Flush
+func (t *translator) saveAsync(save func()) {
+	t.saves.Add(1)
+	go func() {
+		defer t.saves.Done()
+		save()
+	}()
+}
+
+// Flush waits for cache writes in progress, so nothing is lost on exit
+func (t *translator) Flush() {
+	t.saves.Wait()
+}
+
+// conjugationKey identifies a verb in the conjugation cache. Spanish verbs
+// are stored by infinitive alone, as they always have been.
+func conjugationKey(language, verb string) string {
+	if language == "es" {
+		return verb
+	}
+	return language + ":" + verb
+}
+
+// CachedVerbs returns the Spanish verbs with cached conjugations in
+// alphabetical order
+func (t *translator) CachedVerbs() []string {
+	t.load()
+
+	t.cacheMux.RLock()
+	defer t.cacheMux.RUnlock()
+
+	verbs := make([]string, 0, len(t.cache))
+	for verb := range t.cache {
+		if !strings.Contains(verb, ":") {
+			verbs = append(verbs, verb)
+		}
+	}
+	sort.Strings(verbs)
+	return verbs
+}
+
+// getCachedConjugations retrieves conjugations from cache
+func (t *translator) getCachedConjugations(language, verb string) conjugation.Table {
+	t.load()
+
+	t.cacheMux.RLock()
+	defer t.cacheMux.RUnlock()
+
+	if conjugations, exists := t.cache[conjugationKey(language, verb)]; exists {
+		return conjugations
+	}
+	return nil
+}
+
+// cacheConjugations stores conjugations in cache
+func (t *translator) cacheConjugations(language, verb string, conjugations conjugation.Table) {
+	t.load()
+
+	t.cacheMux.Lock()
+	defer t.cacheMux.Unlock()
+
+	t.cache[conjugationKey(language, verb)] = conjugations
+
+	// Save cache asynchronously
+	t.saveAsync(t.saveCache)
+}
+
+// translationKey identifies a translation in the cache. The locales are part
+// of it since regions can translate differently.
+func (t *translator) translationKey(text, from, to string) string {
+	region := t.getRegion()
+	return lang.Locale(from, region) + "|" + lang.Locale(to, region) + "|" + strings.ToLower(strings.TrimSpace(text))
+}
+
+// CachedTranslation returns a translation from the cache, if there is one
+func (t *translator) CachedTranslation(text, from, to string) (*TranslationResult, bool) {
+	t.load()
+	key := t.translationKey(text, from, to)
+
+	t.cacheMux.RLock()
+	defer t.cacheMux.RUnlock()
+
+	cached, ok := t.translated[key]
+	if !ok {
+		return nil, false
+	}
+	result := *cached
+	result.OriginalText = strings.TrimSpace(text) // Keep the caller's capitalization
+	return &result, true
+}
+
+// cacheTranslation stores a translation in cache
+func (t *translator) cacheTranslation(key string, result *TranslationResult) {
+	t.load()
+
+	t.cacheMux.Lock()
+	defer t.cacheMux.Unlock()
+
+	t.translated[key] = result
+
+	// Save cache asynchronously
+	t.saveAsync(func() { t.writeCacheFile(t.translationsFile, t.translated) })
+}
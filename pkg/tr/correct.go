@@ -0,0 +1,42 @@
+package tr
+
+import "tr/pkg/lexicon"
+
+// Correction records an accent fix applied to the input before translating
+type Correction struct {
+	Original  string
+	Corrected string
+}
+
+// lowConfidence is the match score below which a translation is treated as unreliable
+const lowConfidence = 0.5
+
+// IsLowConfidence reports whether a translation looks like a failed lookup:
+// the service echoed the input back or reported a weak match
+func IsLowConfidence(result *TranslationResult) bool {
+	return result.Confidence < lowConfidence ||
+		lexicon.Fold(result.Translation) == lexicon.Fold(result.OriginalText)
+}
+
+// TranslateWithCorrection translates text and, when the lookup fails or is
+// low confidence, retries with accented spellings from the lexicon, so
+// "manana" is looked up as "mañana". The correction is nil if the input was
+// used as typed.
+func TranslateWithCorrection(t Translator, text, from, to string) (*TranslationResult, *Correction, error) {
+	result, err := t.Translate(text, from, to)
+	if err == nil && !IsLowConfidence(result) {
+		return result, nil, nil
+	}
+
+	corrected, changed := lexicon.CorrectAccents(from, text)
+	if !changed {
+		return result, nil, err
+	}
+
+	retry, retryErr := t.Translate(corrected, from, to)
+	if retryErr != nil {
+		return result, nil, err
+	}
+
+	return retry, &Correction{Original: text, Corrected: corrected}, nil
+}
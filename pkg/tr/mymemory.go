@@ -0,0 +1,77 @@
+package tr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// MyMemoryURL is the endpoint of the free MyMemory translation API
+const MyMemoryURL = "https://api.mymemory.translated.net/get"
+
+// myMemory translates with the MyMemory API
+type myMemory struct {
+	client *http.Client
+	url    string
+}
+
+// MyMemory returns the default translation backend, the free MyMemory API
+// at endpoint, or MyMemoryURL when endpoint is empty
+func MyMemory(client *http.Client, endpoint string) TranslationBackend {
+	if endpoint == "" {
+		endpoint = MyMemoryURL
+	}
+	return &myMemory{client: client, url: endpoint}
+}
+
+// Translate looks text up on MyMemory
+func (m *myMemory) Translate(text, from, to string) (string, float64, error) {
+	params := url.Values{}
+	params.Add("q", text)
+	params.Add("langpair", fmt.Sprintf("%s|%s", from, to))
+
+	fullURL := fmt.Sprintf("%s?%s", m.url, params.Encode())
+
+	// Make the HTTP request
+	resp, err := m.client.Get(fullURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to make translation request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", 0, fmt.Errorf("translation service: %w", ErrRateLimited)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("translation service returned status %d", resp.StatusCode)
+	}
+
+	// Read and parse the response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response struct {
+		ResponseData struct {
+			TranslatedText string  `json:"translatedText"`
+			Match          float64 `json:"match"`
+		} `json:"responseData"`
+		ResponseStatus int `json:"responseStatus"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", 0, fmt.Errorf("failed to parse translation response: %w", err)
+	}
+
+	if response.ResponseStatus == http.StatusTooManyRequests {
+		return "", 0, fmt.Errorf("translation service: %w", ErrRateLimited)
+	}
+	if response.ResponseStatus != 200 {
+		return "", 0, fmt.Errorf("translation failed with status %d", response.ResponseStatus)
+	}
+
+	return response.ResponseData.TranslatedText, response.ResponseData.Match, nil
+}
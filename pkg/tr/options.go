@@ -0,0 +1,105 @@
+package tr
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"tr/pkg/conjugation"
+)
+
+// TranslationBackend translates text with a web service or another source.
+// from and to are locales such as "es-MX", see lang.Locale.
+type TranslationBackend interface {
+	Translate(text, from, to string) (translation string, confidence float64, err error)
+}
+
+// ConjugationBackend looks up conjugation tables for languages without an
+// offline conjugator, which today is Spanish
+type ConjugationBackend interface {
+	Conjugate(language, verb string) (conjugation.Table, error)
+}
+
+// Option configures a Translator created by New
+type Option func(*options)
+
+// options collects the settings of New
+type options struct {
+	client         *http.Client
+	cacheDir       string
+	region         string
+	myMemoryURL    string
+	spanishDictURL string
+	now            func() time.Time
+	logger         *log.Logger
+	translations   TranslationBackend
+	conjugations   ConjugationBackend
+}
+
+// WithHTTPClient sets the HTTP client the default backends use
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithCacheDir keeps the conjugation and translation caches as JSON files
+// in dir, so lookups survive restarts and work offline
+func WithCacheDir(dir string) Option {
+	return func(o *options) {
+		o.cacheDir = dir
+	}
+}
+
+// WithMyMemoryURL points the default translation backend at another
+// endpoint, such as a test server
+func WithMyMemoryURL(endpoint string) Option {
+	return func(o *options) {
+		o.myMemoryURL = endpoint
+	}
+}
+
+// WithSpanishDictURL points the default conjugation backend at another
+// site, such as a test server; pages are fetched from <url>/conjugate/<verb>
+func WithSpanishDictURL(baseURL string) Option {
+	return func(o *options) {
+		o.spanishDictURL = baseURL
+	}
+}
+
+// WithClock sets the clock used to time lookups, time.Now by default
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithLogger logs web lookups and cache failures, which are otherwise
+// silent. Nothing is logged by default.
+func WithLogger(logger *log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRegion selects the Spanish variety, e.g. "mexico"; see lang.Regions
+func WithRegion(region string) Option {
+	return func(o *options) {
+		o.region = region
+	}
+}
+
+// WithTranslationBackend replaces MyMemory as the source of translations
+func WithTranslationBackend(backend TranslationBackend) Option {
+	return func(o *options) {
+		o.translations = backend
+	}
+}
+
+// WithConjugationBackend replaces SpanishDict as the source of Spanish
+// conjugations
+func WithConjugationBackend(backend ConjugationBackend) Option {
+	return func(o *options) {
+		o.conjugations = backend
+	}
+}
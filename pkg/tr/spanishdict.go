@@ -0,0 +1,169 @@
+package tr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"tr/pkg/conjugation"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SpanishDictURL is the address of SpanishDict, whose conjugation pages
+// are under /conjugate/
+const SpanishDictURL = "https://www.spanishdict.com"
+
+// spanishDict scrapes Spanish conjugations from SpanishDict
+type spanishDict struct {
+	client *http.Client
+	url    string
+}
+
+// SpanishDict returns the default conjugation backend, which scrapes the
+// present, preterite, imperfect, conditional and future from SpanishDict at
+// baseURL, or SpanishDictURL when baseURL is empty
+func SpanishDict(client *http.Client, baseURL string) ConjugationBackend {
+	if baseURL == "" {
+		baseURL = SpanishDictURL
+	}
+	return &spanishDict{client: client, url: strings.TrimSuffix(baseURL, "/")}
+}
+
+// cleanConjugation removes HTML tags and whitespace from conjugation text
+func (d *spanishDict) cleanConjugation(text string) string {
+	// Remove HTML tags
+	re := regexp.MustCompile(`<[^>]*>`)
+	text = re.ReplaceAllString(text, "")
+
+	// Replace common HTML entities
+	text = strings.ReplaceAll(text, "&nbsp;", " ")
+	text = strings.ReplaceAll(text, "&amp;", "&")
+
+	return strings.TrimSpace(text)
+}
+
+// Conjugate fetches conjugations from SpanishDict using web scraping
+func (d *spanishDict) Conjugate(language, verb string) (conjugation.Table, error) {
+	if language != "es" {
+		return nil, fmt.Errorf("SpanishDict only conjugates Spanish verbs")
+	}
+
+	// Build the SpanishDict URL
+	pageURL := fmt.Sprintf("%s/conjugate/%s", d.url, url.QueryEscape(verb))
+
+	// Make the HTTP request
+	resp, err := d.client.Get(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch conjugations from SpanishDict: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("SpanishDict: %w", ErrRateLimited)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SpanishDict returned status %d", resp.StatusCode)
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SpanishDict response: %w", err)
+	}
+
+	// Parse the HTML to extract conjugations
+	return d.parseSpanishDictHTML(string(body), verb)
+}
+
+// parseSpanishDictHTML extracts conjugation data from SpanishDict HTML using goquery
+func (d *spanishDict) parseSpanishDictHTML(html, verb string) (conjugation.Table, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	// Get the first SpanishDict table which contains the main conjugations
+	firstSpanishDictTable := doc.Find("table.sTe03NLF").First()
+	if firstSpanishDictTable.Length() > 0 {
+		conjugations := d.extractFromSpanishDictTable(firstSpanishDictTable, verb)
+		return conjugations, nil
+	}
+
+	return make(conjugation.Table), nil
+}
+
+// extractFromSpanishDictTable extracts conjugations from SpanishDict's specific table structure
+func (d *spanishDict) extractFromSpanishDictTable(table *goquery.Selection, verb string) conjugation.Table {
+	conjugations := make(conjugation.Table)
+
+	// SpanishDict tables have a predictable order: Present, Preterite, Imperfect, Conditional, Future
+	tenseNames := []string{"present", "preterite", "imperfect", "conditional", "future"}
+
+	// Process each row (skip header)
+	table.Find("tr").Each(func(rowIndex int, row *goquery.Selection) {
+		if rowIndex == 0 {
+			return // Skip header row
+		}
+
+		rowText := strings.TrimSpace(row.Text())
+
+		// Extract pronoun and conjugations from the concatenated text
+		var pronoun string
+
+		if strings.HasPrefix(rowText, "yo") {
+			pronoun = "yo"
+		} else if strings.HasPrefix(rowText, "tú") {
+			pronoun = "tú"
+		} else if strings.HasPrefix(rowText, "él/ella/Ud.") {
+			pronoun = "él/ella"
+		} else if strings.HasPrefix(rowText, "nosotros") {
+			pronoun = "nosotros"
+		} else if strings.HasPrefix(rowText, "vosotros") {
+			pronoun = "vosotros"
+		} else if strings.HasPrefix(rowText, "ellos/ellas/Uds.") {
+			pronoun = "ellos"
+		} else {
+			return // Skip unknown row format
+		}
+
+		// Parse each cell as a different tense
+		cells := row.Find("td")
+		if cells.Length() == len(tenseNames) {
+			cells.Each(func(cellIndex int, cell *goquery.Selection) {
+				if cellIndex >= len(tenseNames) {
+					return
+				}
+
+				tense := tenseNames[cellIndex]
+				form := strings.TrimSpace(cell.Text())
+
+				// Clean up the conjugation
+				form = d.cleanConjugation(form)
+
+				if form != "" && form != "-" && d.isValidConjugation(form, verb) {
+					if conjugations[tense] == nil {
+						conjugations[tense] = make(map[string]string)
+					}
+					conjugations[tense][pronoun] = form
+				}
+			})
+		}
+	})
+
+	return conjugations
+}
+
+// isValidConjugation checks if a string looks like a valid conjugation
+func (d *spanishDict) isValidConjugation(text, verb string) bool {
+	if text == "" || len(text) < 2 || len(text) > 15 {
+		return false
+	}
+
+	// Check if it contains only letters and Spanish characters
+	validChars := regexp.MustCompile(`^[a-záéíóúüñ]+$`)
+	return validChars.MatchString(strings.ToLower(text))
+}
@@ -0,0 +1,207 @@
+// Package tr translates between Spanish, English and other languages and
+// looks up verb conjugations, with optional on-disk caches.
+//
+//	t := tr.New(tr.WithCacheDir(dir), tr.WithRegion("mexico"))
+//	result, err := t.Translate("hola", "es", "en")
+//	table, err := t.GetConjugations("es", "hablar")
+package tr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"tr/pkg/conjugation"
+	"tr/pkg/lang"
+)
+
+// TranslationResult represents the result of a translation
+type TranslationResult struct {
+	OriginalText string   `json:"original_text"`
+	Translation  string   `json:"translation"`
+	IsVerb       bool     `json:"is_verb"`
+	Confidence   float64  `json:"confidence"` // Match quality reported by the service, 0 to 1
+	Definitions  []string `json:"definitions"`
+	Examples     []string `json:"examples"`
+}
+
+// Translator translates text and conjugates verbs. Implementations are safe
+// for concurrent use.
+type Translator interface {
+	Translate(text, from, to string) (*TranslationResult, error)
+	GetConjugations(language, verb string) (conjugation.Table, error)
+	CachedVerbs() []string
+	CachedTranslation(text, from, to string) (*TranslationResult, bool)
+	SetRegion(region string)
+	Flush()
+}
+
+// ErrRateLimited is returned when a web service refuses requests because too
+// many were made; callers can wait and retry
+var ErrRateLimited = errors.New("rate limited")
+
+// translator is the main translator implementation
+type translator struct {
+	translations TranslationBackend
+	conjugations ConjugationBackend
+	now          func() time.Time
+	logger       *log.Logger
+
+	loadOnce         sync.Once // Reads the cache files on first use
+	cache            map[string]conjugation.Table
+	translated       map[string]*TranslationResult // By translationKey
+	cacheMux         sync.RWMutex
+	cacheFile        string // Empty when caches are kept in memory only
+	translationsFile string
+	saveMux          sync.Mutex     // Serializes cache file writes
+	saves            sync.WaitGroup // Cache writes in progress
+
+	regionMux sync.RWMutex
+	region    string // Spanish variety, see lang.Regions
+}
+
+// New creates a translator. Without options it uses MyMemory for
+// translations and SpanishDict for Spanish conjugations, and caches results
+// in memory only. New has no side effects: cache files are read on first
+// use and written as results come in.
+func New(opts ...Option) Translator {
+	o := options{
+		client: &http.Client{Timeout: 15 * time.Second},
+		now:    time.Now,
+		logger: log.New(io.Discard, "", 0),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.translations == nil {
+		o.translations = MyMemory(o.client, o.myMemoryURL)
+	}
+	if o.conjugations == nil {
+		o.conjugations = SpanishDict(o.client, o.spanishDictURL)
+	}
+
+	t := &translator{
+		translations: o.translations,
+		conjugations: o.conjugations,
+		now:          o.now,
+		logger:       o.logger,
+		cache:        make(map[string]conjugation.Table),
+		translated:   make(map[string]*TranslationResult),
+		region:       o.region,
+	}
+
+	if o.cacheDir != "" {
+		t.cacheFile = filepath.Join(o.cacheDir, "conjugations-cache.json")
+		t.translationsFile = filepath.Join(o.cacheDir, "translations-cache.json")
+	}
+
+	return t
+}
+
+// Translate translates text from one language to another. Confident
+// translations are cached, so words looked up before are available offline.
+func (t *translator) Translate(text, from, to string) (*TranslationResult, error) {
+	// Clean and prepare the text
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("empty text provided")
+	}
+
+	if cached, ok := t.CachedTranslation(text, from, to); ok {
+		return cached, nil
+	}
+
+	region := t.getRegion()
+	start := t.now()
+	translation, confidence, err := t.translations.Translate(text, lang.Locale(from, region), lang.Locale(to, region))
+	t.logLookup("translate", text, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the word is likely a verb (simple heuristic, only for languages with conjugations)
+	isVerb := conjugation.IsLikelyVerb(from, text)
+
+	result := &TranslationResult{
+		OriginalText: text,
+		Translation:  translation,
+		IsVerb:       isVerb,
+		Confidence:   confidence,
+		Definitions:  []string{translation},
+		Examples:     []string{},
+	}
+	if !IsLowConfidence(result) {
+		cached := *result
+		t.cacheTranslation(t.translationKey(text, from, to), &cached)
+	}
+	return result, nil
+}
+
+// GetConjugations retrieves verb conjugations for a language. Languages with
+// an offline conjugator use it; others, such as Spanish, are looked up with
+// the conjugation backend and cached. Spanish tables are adapted to the
+// region.
+func (t *translator) GetConjugations(language, verb string) (conjugation.Table, error) {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+
+	if conjugator, ok := conjugation.For(language); ok {
+		return conjugator.Conjugate(verb)
+	}
+	if !lang.SupportsConjugation(language) {
+		return nil, fmt.Errorf("conjugations are not supported for %s", lang.Name(language))
+	}
+
+	// Check cache for verbs
+	conjugations := t.getCachedConjugations(language, verb)
+	if conjugations == nil {
+		var err error
+		start := t.now()
+		conjugations, err = t.conjugations.Conjugate(language, verb)
+		t.logLookup("conjugate", verb, start, err)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache the results if we got any
+		if len(conjugations) > 0 {
+			t.cacheConjugations(language, verb, conjugations)
+		}
+	}
+
+	// Regional forms are derived on the fly so the cache stays region independent
+	if language == "es" {
+		return conjugation.ForRegion(verb, conjugations, t.getRegion()), nil
+	}
+	return conjugations, nil
+}
+
+// SetRegion selects the Spanish variety used for translation locales and
+// conjugation persons, e.g. "mexico"
+func (t *translator) SetRegion(region string) {
+	t.regionMux.Lock()
+	defer t.regionMux.Unlock()
+	t.region = region
+}
+
+// getRegion returns the Spanish variety in use
+func (t *translator) getRegion() string {
+	t.regionMux.RLock()
+	defer t.regionMux.RUnlock()
+	return t.region
+}
+
+// logLookup logs a call to a backend with how long it took
+func (t *translator) logLookup(kind, text string, start time.Time, err error) {
+	elapsed := t.now().Sub(start).Round(time.Millisecond)
+	if err != nil {
+		t.logger.Printf("%s %q failed after %s: %v", kind, text, elapsed, err)
+		return
+	}
+	t.logger.Printf("%s %q took %s", kind, text, elapsed)
+}
@@ -0,0 +1,218 @@
+package tr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// conjugationPage is a trimmed SpanishDict conjugation page for hablar
+const conjugationPage = `<html><body><table class="sTe03NLF">
+<tr><th></th><th>Present</th><th>Preterite</th><th>Imperfect</th><th>Conditional</th><th>Future</th></tr>
+<tr><th>yo</th><td>hablo</td><td>hablé</td><td>hablaba</td><td>hablaría</td><td>hablaré</td></tr>
+<tr><th>tú</th><td>hablas</td><td>hablaste</td><td>hablabas</td><td>hablarías</td><td>hablarás</td></tr>
+<tr><th>él/ella/Ud.</th><td>habla</td><td>habló</td><td>hablaba</td><td>hablaría</td><td>hablará</td></tr>
+<tr><th>nosotros</th><td>hablamos</td><td>hablamos</td><td>hablábamos</td><td>hablaríamos</td><td>hablaremos</td></tr>
+<tr><th>vosotros</th><td>habláis</td><td>hablasteis</td><td>hablabais</td><td>hablaríais</td><td>hablaréis</td></tr>
+<tr><th>ellos/ellas/Uds.</th><td>hablan</td><td>hablaron</td><td>hablaban</td><td>hablarían</td><td>hablarán</td></tr>
+</table></body></html>`
+
+// fakeServices stands in for MyMemory at /get and SpanishDict at /conjugate/
+type fakeServices struct {
+	*httptest.Server
+	requests atomic.Int32
+	langpair atomic.Value // Of the last translation request
+}
+
+func newFakeServices(t *testing.T) *fakeServices {
+	f := &fakeServices{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests.Add(1)
+		switch {
+		case r.URL.Path == "/get" && r.URL.Query().Get("q") == "hola":
+			f.langpair.Store(r.URL.Query().Get("langpair"))
+			fmt.Fprint(w, `{"responseData":{"translatedText":"hello","match":1},"responseStatus":200}`)
+		case r.URL.Path == "/conjugate/hablar":
+			fmt.Fprint(w, conjugationPage)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// options points a translator at the fake services
+func (f *fakeServices) options(extra ...Option) []Option {
+	return append([]Option{
+		WithMyMemoryURL(f.URL + "/get"),
+		WithSpanishDictURL(f.URL),
+	}, extra...)
+}
+
+func TestNewHasNoSideEffects(t *testing.T) {
+	services := newFakeServices(t)
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	New(services.options(WithCacheDir(dir))...)
+
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("New created the cache directory (stat error %v)", err)
+	}
+	if n := services.requests.Load(); n != 0 {
+		t.Errorf("New made %d requests, want none", n)
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	services := newFakeServices(t)
+	dir := t.TempDir()
+
+	first := New(services.options(WithCacheDir(dir), WithRegion("mexico"))...)
+	result, err := first.Translate("hola", "es", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Translation != "hello" {
+		t.Errorf("Translate = %q, want hello", result.Translation)
+	}
+	if got := services.langpair.Load(); got != "es-MX|en" {
+		t.Errorf("langpair = %v, want es-MX|en", got)
+	}
+
+	table, err := first.GetConjugations("es", "hablar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Form("present", "yo"); got != "hablo" {
+		t.Errorf("present yo = %q, want hablo", got)
+	}
+	first.Flush()
+
+	for _, name := range []string{"translations-cache.json", "conjugations-cache.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("cache file %s not written: %v", name, err)
+		}
+	}
+
+	// A new translator answers from the files without asking the services
+	before := services.requests.Load()
+	second := New(services.options(WithCacheDir(dir), WithRegion("mexico"))...)
+
+	cached, err := second.Translate("Hola", "es", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.Translation != "hello" || cached.OriginalText != "Hola" {
+		t.Errorf("cached Translate = %q -> %q, want Hola -> hello", cached.OriginalText, cached.Translation)
+	}
+
+	table, err = second.GetConjugations("es", "hablar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Form("future", "nosotros"); got != "hablaremos" {
+		t.Errorf("cached future nosotros = %q, want hablaremos", got)
+	}
+	if verbs := second.CachedVerbs(); !slices.Equal(verbs, []string{"hablar"}) {
+		t.Errorf("CachedVerbs = %v, want [hablar]", verbs)
+	}
+	if n := services.requests.Load() - before; n != 0 {
+		t.Errorf("cached lookups made %d requests, want none", n)
+	}
+
+	// Translations are cached per locale, so another region asks again
+	if _, ok := New(WithCacheDir(dir)).CachedTranslation("hola", "es", "en"); ok {
+		t.Error("translation cached for es-MX was returned for the default region")
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		lookup func(Translator) error
+	}{
+		{
+			name:   "MyMemory status",
+			status: http.StatusTooManyRequests,
+			lookup: func(t Translator) error { _, err := t.Translate("hola", "es", "en"); return err },
+		},
+		{
+			name:   "MyMemory responseStatus",
+			status: http.StatusOK,
+			body:   `{"responseData":{"translatedText":"MYMEMORY WARNING"},"responseStatus":429}`,
+			lookup: func(t Translator) error { _, err := t.Translate("hola", "es", "en"); return err },
+		},
+		{
+			name:   "SpanishDict status",
+			status: http.StatusTooManyRequests,
+			lookup: func(t Translator) error { _, err := t.GetConjugations("es", "hablar"); return err },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			tr := New(WithMyMemoryURL(srv.URL), WithSpanishDictURL(srv.URL))
+			if err := tt.lookup(tr); !errors.Is(err, ErrRateLimited) {
+				t.Errorf("error = %v, want ErrRateLimited", err)
+			}
+		})
+	}
+}
+
+func TestServiceErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	tr := New(WithMyMemoryURL(srv.URL), WithSpanishDictURL(srv.URL))
+	if _, err := tr.Translate("hola", "es", "en"); err == nil || errors.Is(err, ErrRateLimited) {
+		t.Errorf("Translate error = %v, want a non rate limit error", err)
+	}
+	if _, err := tr.GetConjugations("es", "hablar"); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("GetConjugations error = %v, want status 500", err)
+	}
+}
+
+func TestClockAndLogger(t *testing.T) {
+	services := newFakeServices(t)
+
+	var logs bytes.Buffer
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(250 * time.Millisecond)
+		return now
+	}
+
+	tr := New(services.options(WithClock(clock), WithLogger(log.New(&logs, "", 0)))...)
+	if _, err := tr.Translate("hola", "es", "en"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.GetConjugations("es", "nope"); err == nil {
+		t.Fatal("GetConjugations of an unknown page succeeded")
+	}
+
+	want := "translate \"hola\" took 250ms\nconjugate \"nope\" failed after 250ms: SpanishDict returned status 404\n"
+	if logs.String() != want {
+		t.Errorf("logs = %q, want %q", logs.String(), want)
+	}
+}
@@ -22,7 +22,7 @@ func main() {
 	
 	// Test verb conjugation
 	if result.IsVerb {
-		conjugations, err := t.GetConjugations("hola")
+		conjugations, err := t.GetConjugations("es", "hola")
 		if err == nil {
 			fmt.Printf("Found %d conjugation sets\n", len(conjugations))
 		}
@@ -38,7 +38,7 @@ func main() {
 		verbResult.OriginalText, verbResult.Translation, verbResult.IsVerb)
 	
 	if verbResult.IsVerb {
-		conjugations, err := t.GetConjugations("caminar")
+		conjugations, err := t.GetConjugations("es", "caminar")
 		if err == nil && len(conjugations) > 0 {
 			fmt.Println("Conjugations found:")
 			for tense, persons := range conjugations {